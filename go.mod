@@ -5,6 +5,7 @@ go 1.25
 require (
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/gorilla/websocket v1.5.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require golang.org/x/net v0.46.0 // indirect
@@ -0,0 +1,313 @@
+package tavo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBaseURL is the API endpoint used when none is configured
+const DefaultBaseURL = "https://api.tavo.ai"
+
+// DefaultAPIVersion is the API version used when none is configured
+const DefaultAPIVersion = "v1"
+
+// DefaultProfile is the profile read from the config file when none is selected
+const DefaultProfile = "default"
+
+// Option configures a Client created by NewClient
+type Option func(*clientOptions)
+
+// clientOptions collects option values before they are applied to a Client
+type clientOptions struct {
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	timeout         time.Duration
+	apiVersion      string
+	userAgentSuffix string
+	retryPolicy     *RetryPolicy
+	credentialStore CredentialStore
+	profile         string
+	reauthenticator Reauthenticator
+}
+
+// WithHTTPClient uses httpClient for all requests instead of a new http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the RoundTripper used to send requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP request
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithAPIVersion selects the API version, e.g. "v2". A value starting with "/"
+// is used verbatim as the path prefix for every endpoint.
+func WithAPIVersion(version string) Option {
+	return func(o *clientOptions) {
+		o.apiVersion = version
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the SDK's User-Agent header
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+// WithRetryPolicy sets the retry policy. A nil policy disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) {
+		if policy == nil {
+			policy = NoRetries()
+		}
+		o.retryPolicy = policy
+	}
+}
+
+// WithMaxRetries keeps the default retry policy but allows up to n retries
+func WithMaxRetries(n int) Option {
+	return func(o *clientOptions) {
+		policy := DefaultRetryPolicy()
+		if o.retryPolicy != nil {
+			copied := *o.retryPolicy
+			policy = &copied
+		}
+		policy.MaxAttempts = n + 1
+		o.retryPolicy = policy
+	}
+}
+
+// apply copies the collected options onto c
+func (o *clientOptions) apply(c *Client) {
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		httpClient = &copied
+	}
+	if o.transport != nil {
+		httpClient.Transport = o.transport
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+	c.httpClient = httpClient
+
+	c.apiPath = "/api/" + DefaultAPIVersion
+	if version := strings.TrimSpace(o.apiVersion); version != "" {
+		if strings.HasPrefix(version, "/") {
+			c.apiPath = strings.TrimSuffix(version, "/")
+		} else {
+			c.apiPath = "/api/" + strings.Trim(version, "/")
+		}
+	}
+
+	c.userAgent = userAgent
+	if o.userAgentSuffix != "" {
+		c.userAgent += " " + o.userAgentSuffix
+	}
+
+	c.retryPolicy = DefaultRetryPolicy()
+	if o.retryPolicy != nil {
+		c.retryPolicy = o.retryPolicy
+	}
+
+	c.credentialStore = o.credentialStore
+	c.profile = profileName(o.profile)
+	c.reauthenticator = o.reauthenticator
+}
+
+// Config holds client settings loaded from the environment or a profile file
+type Config struct {
+	APIKey          string        `yaml:"api_key"`
+	DeviceToken     string        `yaml:"device_token"`
+	BaseURL         string        `yaml:"base_url"`
+	APIVersion      string        `yaml:"api_version"`
+	Timeout         time.Duration `yaml:"timeout"`
+	MaxRetries      *int          `yaml:"max_retries"`
+	UserAgentSuffix string        `yaml:"user_agent_suffix"`
+}
+
+// configFile is the layout of the YAML config file
+type configFile struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]*Config `yaml:"profiles"`
+}
+
+// DefaultConfigPath returns the config file location: $TAVO_CONFIG_FILE if set,
+// otherwise $XDG_CONFIG_HOME/tavo/config.yaml, falling back to ~/.config/tavo/config.yaml
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv("TAVO_CONFIG_FILE"); path != "" {
+		return path, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "tavo", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tavo", "config.yaml"), nil
+}
+
+// LoadConfigFile reads a named profile from the YAML config file at path.
+// An empty profile selects the file's default_profile, or "default".
+func LoadConfigFile(path, profile string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file configFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	cfg, ok := file.Profiles[profile]
+	if !ok || cfg == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+	return cfg, nil
+}
+
+// ConfigFromEnv reads TAVO_API_KEY, TAVO_DEVICE_TOKEN, TAVO_BASE_URL, TAVO_API_VERSION,
+// TAVO_TIMEOUT (a Go duration such as "30s") and TAVO_MAX_RETRIES
+func ConfigFromEnv() (*Config, error) {
+	cfg := &Config{
+		APIKey:      os.Getenv("TAVO_API_KEY"),
+		DeviceToken: os.Getenv("TAVO_DEVICE_TOKEN"),
+		BaseURL:     os.Getenv("TAVO_BASE_URL"),
+		APIVersion:  os.Getenv("TAVO_API_VERSION"),
+	}
+
+	if value := os.Getenv("TAVO_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TAVO_TIMEOUT: %w", err)
+		}
+		cfg.Timeout = timeout
+	}
+
+	if value := os.Getenv("TAVO_MAX_RETRIES"); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TAVO_MAX_RETRIES: %w", err)
+		}
+		cfg.MaxRetries = &retries
+	}
+
+	return cfg, nil
+}
+
+// LoadConfig loads the profile named by $TAVO_PROFILE (or the file's default) from
+// the config file, if one exists, and overlays any TAVO_* environment variables
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	fileCfg, err := LoadConfigFile(path, os.Getenv("TAVO_PROFILE"))
+	switch {
+	case err == nil:
+		cfg = fileCfg
+	case errors.Is(err, os.ErrNotExist) && os.Getenv("TAVO_CONFIG_FILE") == "":
+		// No config file is fine; the environment may provide everything
+	default:
+		return nil, err
+	}
+
+	envCfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	cfg.merge(envCfg)
+
+	return cfg, nil
+}
+
+// merge overrides cfg with every field that is set in other
+func (cfg *Config) merge(other *Config) {
+	if other.APIKey != "" || other.DeviceToken != "" {
+		cfg.APIKey = other.APIKey
+		cfg.DeviceToken = other.DeviceToken
+	}
+	if other.BaseURL != "" {
+		cfg.BaseURL = other.BaseURL
+	}
+	if other.APIVersion != "" {
+		cfg.APIVersion = other.APIVersion
+	}
+	if other.Timeout != 0 {
+		cfg.Timeout = other.Timeout
+	}
+	if other.MaxRetries != nil {
+		cfg.MaxRetries = other.MaxRetries
+	}
+	if other.UserAgentSuffix != "" {
+		cfg.UserAgentSuffix = other.UserAgentSuffix
+	}
+}
+
+// Options converts the settings in cfg into client options
+func (cfg *Config) Options() []Option {
+	var opts []Option
+	if cfg.APIVersion != "" {
+		opts = append(opts, WithAPIVersion(cfg.APIVersion))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+	if cfg.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*cfg.MaxRetries))
+	}
+	if cfg.UserAgentSuffix != "" {
+		opts = append(opts, WithUserAgentSuffix(cfg.UserAgentSuffix))
+	}
+	return opts
+}
+
+// NewClientFromConfig creates a client from cfg. Options in opts are applied after
+// the ones derived from cfg, so they take precedence.
+func NewClientFromConfig(cfg *Config, opts ...Option) *Client {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	return NewClient(cfg.APIKey, cfg.DeviceToken, cfg.BaseURL, append(cfg.Options(), opts...)...)
+}
+
+// NewClientFromEnv creates a client from the config file and TAVO_* environment variables
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromConfig(cfg, opts...), nil
+}
@@ -0,0 +1,248 @@
+package tavo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv unsets every TAVO_* variable and points the config path at an empty directory
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"TAVO_API_KEY", "TAVO_DEVICE_TOKEN", "TAVO_BASE_URL", "TAVO_API_VERSION",
+		"TAVO_TIMEOUT", "TAVO_MAX_RETRIES", "TAVO_PROFILE", "TAVO_CONFIG_FILE",
+	} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+// writeConfigFile writes a profile file and points TAVO_CONFIG_FILE at it
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TAVO_CONFIG_FILE", path)
+	return path
+}
+
+const testConfigFile = `default_profile: staging
+profiles:
+  default:
+    api_key: default-key
+  staging:
+    api_key: staging-key
+    base_url: https://staging.tavo.ai
+    api_version: v2
+    timeout: 45s
+    max_retries: 1
+    user_agent_suffix: ci/1.0
+  device:
+    device_token: device-token
+`
+
+func TestConfigFromEnv(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("TAVO_API_KEY", "env-key")
+	t.Setenv("TAVO_BASE_URL", "https://eu.tavo.ai")
+	t.Setenv("TAVO_API_VERSION", "v3")
+	t.Setenv("TAVO_TIMEOUT", "1m30s")
+	t.Setenv("TAVO_MAX_RETRIES", "0")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv: %v", err)
+	}
+	if cfg.APIKey != "env-key" || cfg.BaseURL != "https://eu.tavo.ai" || cfg.APIVersion != "v3" || cfg.Timeout != 90*time.Second {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.MaxRetries == nil || *cfg.MaxRetries != 0 {
+		t.Errorf("MaxRetries = %v, want 0", cfg.MaxRetries)
+	}
+
+	for name, value := range map[string]string{"TAVO_TIMEOUT": "30", "TAVO_MAX_RETRIES": "many"} {
+		t.Run(name, func(t *testing.T) {
+			clearConfigEnv(t)
+			t.Setenv(name, value)
+			if _, err := ConfigFromEnv(); err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("ConfigFromEnv = %v, want an error naming %s", err, name)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfigFile(t, testConfigFile)
+
+	tests := []struct {
+		profile    string
+		wantAPIKey string
+		wantToken  string
+	}{
+		{"", "staging-key", ""},
+		{"default", "default-key", ""},
+		{"device", "", "device-token"},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadConfigFile(path, tt.profile)
+		if err != nil {
+			t.Fatalf("LoadConfigFile(%q): %v", tt.profile, err)
+		}
+		if cfg.APIKey != tt.wantAPIKey || cfg.DeviceToken != tt.wantToken {
+			t.Errorf("LoadConfigFile(%q) = %+v", tt.profile, cfg)
+		}
+	}
+
+	cfg, err := LoadConfigFile(path, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BaseURL != "https://staging.tavo.ai" || cfg.APIVersion != "v2" || cfg.Timeout != 45*time.Second ||
+		cfg.MaxRetries == nil || *cfg.MaxRetries != 1 || cfg.UserAgentSuffix != "ci/1.0" {
+		t.Errorf("staging = %+v", cfg)
+	}
+
+	if _, err := LoadConfigFile(path, "missing"); err == nil {
+		t.Error("LoadConfigFile accepted a missing profile")
+	}
+	noDefault := writeConfigFile(t, "profiles:\n  default:\n    api_key: k\n")
+	if cfg, err := LoadConfigFile(noDefault, ""); err != nil || cfg.APIKey != "k" {
+		t.Errorf("without default_profile = %+v, %v; want the default profile", cfg, err)
+	}
+	invalid := writeConfigFile(t, "profiles: [\n")
+	if _, err := LoadConfigFile(invalid, ""); err == nil {
+		t.Error("LoadConfigFile accepted invalid YAML")
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "no file",
+			env:  map[string]string{"TAVO_CONFIG_FILE": "", "TAVO_API_KEY": "env-key"},
+			want: Config{APIKey: "env-key"},
+		},
+		{
+			name: "file default profile",
+			file: testConfigFile,
+			want: Config{APIKey: "staging-key", BaseURL: "https://staging.tavo.ai", APIVersion: "v2", Timeout: 45 * time.Second, UserAgentSuffix: "ci/1.0"},
+		},
+		{
+			name: "profile from env",
+			file: testConfigFile,
+			env:  map[string]string{"TAVO_PROFILE": "default"},
+			want: Config{APIKey: "default-key"},
+		},
+		{
+			name: "env overrides profile",
+			file: testConfigFile,
+			env:  map[string]string{"TAVO_BASE_URL": "https://eu.tavo.ai", "TAVO_TIMEOUT": "5s"},
+			want: Config{APIKey: "staging-key", BaseURL: "https://eu.tavo.ai", APIVersion: "v2", Timeout: 5 * time.Second, UserAgentSuffix: "ci/1.0"},
+		},
+		{
+			name: "env token replaces profile key",
+			file: testConfigFile,
+			env:  map[string]string{"TAVO_DEVICE_TOKEN": "env-token"},
+			want: Config{DeviceToken: "env-token", BaseURL: "https://staging.tavo.ai", APIVersion: "v2", Timeout: 45 * time.Second, UserAgentSuffix: "ci/1.0"},
+		},
+		{
+			name:    "missing profile",
+			file:    testConfigFile,
+			env:     map[string]string{"TAVO_PROFILE": "prod"},
+			wantErr: true,
+		},
+		{
+			name:    "missing explicit file",
+			env:     map[string]string{"TAVO_CONFIG_FILE": "/nonexistent/tavo.yaml"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+			if tt.file != "" {
+				writeConfigFile(t, tt.file)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("LoadConfig = %+v, want an error", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			got := *cfg
+			got.MaxRetries = nil
+			if got != tt.want {
+				t.Errorf("LoadConfig = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigXDGPath(t *testing.T) {
+	clearConfigEnv(t)
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if err := os.MkdirAll(filepath.Join(dir, "tavo"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tavo", "config.yaml"), []byte(testConfigFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil || cfg.APIKey != "staging-key" {
+		t.Errorf("LoadConfig = %+v, %v; want the staging profile", cfg, err)
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	clearConfigEnv(t)
+	writeConfigFile(t, testConfigFile)
+	t.Setenv("TAVO_MAX_RETRIES", "4")
+
+	client, err := NewClientFromEnv(WithTimeout(time.Second), WithUserAgentSuffix("explicit"))
+	if err != nil {
+		t.Fatalf("NewClientFromEnv: %v", err)
+	}
+	if client.apiKey != "staging-key" || client.baseURL != "https://staging.tavo.ai" || client.apiPath != "/api/v2" {
+		t.Errorf("client credentials and endpoint = %q, %q, %q", client.apiKey, client.baseURL, client.apiPath)
+	}
+	// Explicit options win over both the profile and the environment
+	if client.httpClient.Timeout != time.Second || !strings.HasSuffix(client.userAgent, " explicit") {
+		t.Errorf("timeout, user agent = %v, %q", client.httpClient.Timeout, client.userAgent)
+	}
+	if client.retryPolicy.MaxAttempts != 5 {
+		t.Errorf("MaxAttempts = %d, want TAVO_MAX_RETRIES + 1", client.retryPolicy.MaxAttempts)
+	}
+
+	t.Setenv("TAVO_TIMEOUT", "soon")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Error("NewClientFromEnv accepted an invalid TAVO_TIMEOUT")
+	}
+}
+
+func TestNewClientFromConfigDefaults(t *testing.T) {
+	clearConfigEnv(t)
+	client := NewClientFromConfig(nil)
+	if client.baseURL != DefaultBaseURL || client.apiPath != "/api/"+DefaultAPIVersion || client.userAgent != userAgent {
+		t.Errorf("client = %q, %q, %q", client.baseURL, client.apiPath, client.userAgent)
+	}
+}
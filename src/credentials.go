@@ -0,0 +1,427 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrCredentialsNotFound is returned by a CredentialStore that holds nothing for
+// the requested profile and base URL
+var ErrCredentialsNotFound = errors.New("tavo: no stored credentials")
+
+// Credentials authenticate a client. An API key takes precedence over a device token.
+type Credentials struct {
+	APIKey       string    `json:"api_key,omitempty"`
+	DeviceToken  string    `json:"device_token,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+}
+
+// IsZero reports whether c holds neither an API key nor a device token
+func (c *Credentials) IsZero() bool {
+	return c == nil || (c.APIKey == "" && c.DeviceToken == "")
+}
+
+// CredentialStore persists credentials per profile and API base URL
+type CredentialStore interface {
+	// Load returns ErrCredentialsNotFound when nothing is stored
+	Load(profile, baseURL string) (*Credentials, error)
+	Save(profile, baseURL string, creds *Credentials) error
+	Delete(profile, baseURL string) error
+}
+
+// DefaultCredentialsPath returns the credentials file next to the config file
+func DefaultCredentialsPath() (string, error) {
+	configPath, err := DefaultConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "credentials.json"), nil
+}
+
+// FileCredentialStore keeps credentials in a JSON file readable only by the owner
+type FileCredentialStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// credentialsFile is the on-disk layout: profile -> base URL -> credentials
+type credentialsFile struct {
+	Profiles map[string]map[string]*Credentials `json:"profiles"`
+}
+
+// NewFileCredentialStore creates a store backed by path. An empty path uses DefaultCredentialsPath.
+func NewFileCredentialStore(path string) (*FileCredentialStore, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultCredentialsPath(); err != nil {
+			return nil, err
+		}
+	}
+	return &FileCredentialStore{path: path}, nil
+}
+
+// Path returns the location of the credentials file
+func (s *FileCredentialStore) Path() string {
+	return s.path
+}
+
+// Load implements CredentialStore
+func (s *FileCredentialStore) Load(profile, baseURL string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	creds := file.Profiles[profileName(profile)][baseURL]
+	if creds.IsZero() {
+		return nil, ErrCredentialsNotFound
+	}
+	return creds, nil
+}
+
+// Save implements CredentialStore
+func (s *FileCredentialStore) Save(profile, baseURL string, creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	profile = profileName(profile)
+	if file.Profiles[profile] == nil {
+		file.Profiles[profile] = make(map[string]*Credentials)
+	}
+	file.Profiles[profile][baseURL] = creds
+	return s.write(file)
+}
+
+// Delete implements CredentialStore
+func (s *FileCredentialStore) Delete(profile, baseURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	profile = profileName(profile)
+	delete(file.Profiles[profile], baseURL)
+	if len(file.Profiles[profile]) == 0 {
+		delete(file.Profiles, profile)
+	}
+	return s.write(file)
+}
+
+// read loads the credentials file, treating a missing file as empty
+func (s *FileCredentialStore) read() (*credentialsFile, error) {
+	file := &credentialsFile{Profiles: make(map[string]map[string]*Credentials)}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("parse credentials file %s: %w", s.path, err)
+	}
+	if file.Profiles == nil {
+		file.Profiles = make(map[string]map[string]*Credentials)
+	}
+	return file, nil
+}
+
+// write atomically replaces the credentials file with 0600 permissions
+func (s *FileCredentialStore) write(file *credentialsFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(dir, ".credentials-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if err := tempFile.Chmod(0o600); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), s.path)
+}
+
+// MemoryCredentialStore keeps credentials in memory, mainly for tests
+type MemoryCredentialStore struct {
+	mu          sync.Mutex
+	credentials map[string]Credentials
+}
+
+// NewMemoryCredentialStore creates an empty in-memory store
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{credentials: make(map[string]Credentials)}
+}
+
+// Load implements CredentialStore
+func (s *MemoryCredentialStore) Load(profile, baseURL string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.credentials[profileName(profile)+"\x00"+baseURL]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+// Save implements CredentialStore
+func (s *MemoryCredentialStore) Save(profile, baseURL string, creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.credentials[profileName(profile)+"\x00"+baseURL] = *creds
+	return nil
+}
+
+// Delete implements CredentialStore
+func (s *MemoryCredentialStore) Delete(profile, baseURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.credentials, profileName(profile)+"\x00"+baseURL)
+	return nil
+}
+
+// profileName defaults an empty profile to DefaultProfile
+func profileName(profile string) string {
+	if profile == "" {
+		return DefaultProfile
+	}
+	return profile
+}
+
+// CredentialSource yields credentials, or nil when it has none
+type CredentialSource func() (*Credentials, error)
+
+// CredentialChain tries each source in order and uses the first that has credentials
+type CredentialChain []CredentialSource
+
+// Resolve returns the first non-empty credentials in the chain
+func (chain CredentialChain) Resolve() (*Credentials, error) {
+	for _, source := range chain {
+		creds, err := source()
+		if err != nil {
+			return nil, err
+		}
+		if !creds.IsZero() {
+			return creds, nil
+		}
+	}
+	return nil, ErrCredentialsNotFound
+}
+
+// StaticCredentials is a source for an explicit API key or device token
+func StaticCredentials(apiKey, deviceToken string) CredentialSource {
+	return func() (*Credentials, error) {
+		return &Credentials{APIKey: apiKey, DeviceToken: deviceToken}, nil
+	}
+}
+
+// EnvCredentials is a source for TAVO_API_KEY and TAVO_DEVICE_TOKEN
+func EnvCredentials() CredentialSource {
+	return func() (*Credentials, error) {
+		return &Credentials{APIKey: os.Getenv("TAVO_API_KEY"), DeviceToken: os.Getenv("TAVO_DEVICE_TOKEN")}, nil
+	}
+}
+
+// StoredCredentials is a source for credentials saved in store
+func StoredCredentials(store CredentialStore, profile, baseURL string) CredentialSource {
+	return func() (*Credentials, error) {
+		creds, err := store.Load(profile, baseURL)
+		if errors.Is(err, ErrCredentialsNotFound) {
+			return nil, nil
+		}
+		return creds, err
+	}
+}
+
+// Reauthenticator obtains new credentials after the API rejected the current
+// ones, typically by running a DeviceFlow. It must install them on client.
+type Reauthenticator func(ctx context.Context, client *Client) error
+
+// WithCredentialStore loads credentials from store when none are passed to
+// NewClient or set in the environment, and persists tokens obtained later
+func WithCredentialStore(store CredentialStore, profile string) Option {
+	return func(o *clientOptions) {
+		o.credentialStore = store
+		o.profile = profile
+	}
+}
+
+// WithReauthenticator sets the fallback used when a 401 cannot be resolved by
+// refreshing the device token
+func WithReauthenticator(fn Reauthenticator) Option {
+	return func(o *clientOptions) {
+		o.reauthenticator = fn
+	}
+}
+
+// Credentials returns a snapshot of the client's current credentials
+func (c *Client) Credentials() Credentials {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return Credentials{
+		APIKey:       c.apiKey,
+		DeviceToken:  c.deviceToken,
+		RefreshToken: c.refreshToken,
+		ExpiresAt:    c.tokenExpiry,
+	}
+}
+
+// CredentialsError returns the error that kept NewClient from loading stored
+// credentials, or nil. Requests rejected with a 401 also report it.
+func (c *Client) CredentialsError() error {
+	return c.credentialErr
+}
+
+// unauthorized adds the reason stored credentials could not be loaded to a 401
+func (c *Client) unauthorized(apiErr *APIError) error {
+	if apiErr.StatusCode == http.StatusUnauthorized && c.credentialErr != nil {
+		return errors.Join(apiErr, c.credentialErr)
+	}
+	return apiErr
+}
+
+// setCredentials installs creds on the client without persisting them
+func (c *Client) setCredentials(creds *Credentials) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.apiKey = creds.APIKey
+	c.deviceToken = creds.DeviceToken
+	c.refreshToken = creds.RefreshToken
+	c.tokenExpiry = creds.ExpiresAt
+	if c.apiKey != "" {
+		c.deviceToken = ""
+	}
+}
+
+// installDeviceToken switches the client to token and persists it when a
+// credential store is configured
+func (c *Client) installDeviceToken(token *DeviceToken) error {
+	creds := &Credentials{DeviceToken: token.AccessToken, RefreshToken: token.RefreshToken}
+	if token.ExpiresIn > 0 {
+		creds.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	c.setCredentials(creds)
+
+	if c.credentialStore == nil {
+		return nil
+	}
+	return c.credentialStore.Save(c.profile, c.baseURL, creds)
+}
+
+// credentialToken returns the secret currently used to authenticate
+func (c *Client) credentialToken() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	if c.apiKey != "" {
+		return c.apiKey
+	}
+	return c.deviceToken
+}
+
+// canReauthenticate reports whether a 401 may be recovered from
+func (c *Client) canReauthenticate(ctx context.Context) bool {
+	if ctx.Value(noReauthContextKey{}) != nil {
+		return false
+	}
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.refreshToken != "" || c.credentialStore != nil || c.reauthenticator != nil
+}
+
+type noReauthContextKey struct{}
+
+// reauthenticate replaces credentials rejected with a 401. staleToken is the
+// secret the failed request was sent with; if another goroutine has replaced it
+// in the meantime nothing is done.
+func (c *Client) reauthenticate(ctx context.Context, staleToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.credentialToken() != staleToken {
+		return nil
+	}
+
+	if c.Credentials().RefreshToken != "" {
+		if err := c.refreshDeviceToken(ctx); err == nil {
+			return nil
+		}
+	}
+
+	// Another process may have logged in since the client was created
+	if c.credentialStore != nil {
+		creds, err := c.credentialStore.Load(c.profile, c.baseURL)
+		if err == nil && !creds.IsZero() && creds.APIKey+creds.DeviceToken != staleToken {
+			c.setCredentials(creds)
+			return nil
+		}
+	}
+
+	if c.reauthenticator != nil {
+		return c.reauthenticator(context.WithValue(ctx, noReauthContextKey{}, true), c)
+	}
+	return errors.New("tavo: credentials were rejected and could not be refreshed")
+}
+
+// refreshDeviceToken exchanges the refresh token for a new device token
+func (c *Client) refreshDeviceToken(ctx context.Context) error {
+	body := map[string]interface{}{
+		"grant_type":    "refresh_token",
+		"refresh_token": c.Credentials().RefreshToken,
+	}
+
+	var token DeviceToken
+	ctx = context.WithValue(ctx, noReauthContextKey{}, true)
+	if err := c.do(ctx, http.MethodPost, "/token", nil, body, &token); err != nil {
+		return err
+	}
+	if token.AccessToken == "" {
+		return errors.New("tavo: refresh response did not include an access_token")
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = c.Credentials().RefreshToken
+	}
+	return c.installDeviceToken(&token)
+}
+
+// Logout clears the client's credentials and removes them from the credential store
+func (c *Client) Logout() error {
+	c.setCredentials(&Credentials{})
+	if c.credentialStore == nil {
+		return nil
+	}
+	return c.credentialStore.Delete(c.profile, c.baseURL)
+}
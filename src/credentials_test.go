@@ -0,0 +1,175 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clearCredentialEnv keeps credentials in the environment out of a test
+func clearCredentialEnv(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "")
+	t.Setenv("TAVO_DEVICE_TOKEN", "")
+}
+
+// failingStore is a CredentialStore that cannot be read
+type failingStore struct {
+	err error
+}
+
+func (s failingStore) Load(profile, baseURL string) (*Credentials, error)     { return nil, s.err }
+func (s failingStore) Save(profile, baseURL string, creds *Credentials) error { return s.err }
+func (s failingStore) Delete(profile, baseURL string) error                   { return s.err }
+
+// tokenServer accepts requests carrying the token issued by its /token endpoint
+type tokenServer struct {
+	*httptest.Server
+	refreshes atomic.Int32
+	current   atomic.Value
+	delay     time.Duration
+}
+
+func newTokenServer(t *testing.T, token string) *tokenServer {
+	t.Helper()
+	server := &tokenServer{}
+	server.current.Store(token)
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/token" {
+			n := server.refreshes.Add(1)
+			time.Sleep(server.delay)
+			token := fmt.Sprintf("token-%d", n)
+			server.current.Store(token)
+			fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"refresh-%d","expires_in":3600}`, token, n)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+server.current.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewClientReadsEnvironmentWithoutStore(t *testing.T) {
+	clearCredentialEnv(t)
+	t.Setenv("TAVO_DEVICE_TOKEN", "env-token")
+
+	client := NewClient("", "", "https://api.example.com")
+	if creds := client.Credentials(); creds.DeviceToken != "env-token" {
+		t.Errorf("DeviceToken = %q, want env-token", creds.DeviceToken)
+	}
+	if err := client.CredentialsError(); err != nil {
+		t.Errorf("CredentialsError() = %v", err)
+	}
+}
+
+func TestNewClientPrefersEnvironmentOverStore(t *testing.T) {
+	clearCredentialEnv(t)
+	t.Setenv("TAVO_API_KEY", "env-key")
+	store := NewMemoryCredentialStore()
+	store.Save("", "https://api.example.com", &Credentials{APIKey: "stored-key"})
+
+	client := NewClient("", "", "https://api.example.com", WithCredentialStore(store, ""))
+	if creds := client.Credentials(); creds.APIKey != "env-key" {
+		t.Errorf("APIKey = %q, want env-key", creds.APIKey)
+	}
+
+	clearCredentialEnv(t)
+	client = NewClient("", "", "https://api.example.com", WithCredentialStore(store, ""))
+	if creds := client.Credentials(); creds.APIKey != "stored-key" {
+		t.Errorf("APIKey = %q, want stored-key", creds.APIKey)
+	}
+}
+
+func TestNewClientReportsStoreErrors(t *testing.T) {
+	clearCredentialEnv(t)
+	storeErr := errors.New("credentials file is corrupt")
+	server := newTokenServer(t, "unused")
+
+	client := NewClient("", "", server.URL, WithCredentialStore(failingStore{storeErr}, ""), WithRetryPolicy(NoRetries()))
+	if err := client.CredentialsError(); !errors.Is(err, storeErr) {
+		t.Fatalf("CredentialsError() = %v, want %v", err, storeErr)
+	}
+
+	err := client.do(context.Background(), http.MethodGet, "/health", nil, nil, nil)
+	if !IsUnauthorized(err) || !errors.Is(err, storeErr) {
+		t.Errorf("err = %v, want a 401 that reports the store error", err)
+	}
+
+	// A missing entry is not an error
+	client = NewClient("", "", server.URL, WithCredentialStore(NewMemoryCredentialStore(), ""))
+	if err := client.CredentialsError(); err != nil {
+		t.Errorf("CredentialsError() with an empty store = %v", err)
+	}
+}
+
+func TestStoredTokenIsRefreshedAndSaved(t *testing.T) {
+	clearCredentialEnv(t)
+	server := newTokenServer(t, "not-yet-issued")
+	store := NewMemoryCredentialStore()
+	store.Save("work", server.URL, &Credentials{DeviceToken: "expired", RefreshToken: "refresh-0"})
+
+	client := NewClient("", "", server.URL, WithCredentialStore(store, "work"))
+	if creds := client.Credentials(); creds.DeviceToken != "expired" {
+		t.Fatalf("DeviceToken = %q, want the stored token", creds.DeviceToken)
+	}
+
+	var out map[string]interface{}
+	if err := client.do(context.Background(), http.MethodGet, "/health", nil, nil, &out); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if out["status"] != "ok" || server.refreshes.Load() != 1 {
+		t.Errorf("out = %v, refreshes = %d", out, server.refreshes.Load())
+	}
+
+	saved, err := store.Load("work", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.DeviceToken != "token-1" || saved.RefreshToken != "refresh-1" || saved.ExpiresAt.IsZero() {
+		t.Errorf("saved credentials = %+v", saved)
+	}
+	if creds := client.Credentials(); creds.DeviceToken != "token-1" {
+		t.Errorf("client DeviceToken = %q, want token-1", creds.DeviceToken)
+	}
+}
+
+func TestConcurrentUnauthorizedRequestsRefreshOnce(t *testing.T) {
+	clearCredentialEnv(t)
+	server := newTokenServer(t, "not-yet-issued")
+	// Keep the refresh in flight while the other requests get their 401
+	server.delay = 50 * time.Millisecond
+	store := NewMemoryCredentialStore()
+	store.Save("", server.URL, &Credentials{DeviceToken: "expired", RefreshToken: "refresh-0"})
+	client := NewClient("", "", server.URL, WithCredentialStore(store, ""))
+
+	const requests = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.do(context.Background(), http.MethodGet, "/health", nil, nil, nil)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("do: %v", err)
+		}
+	}
+	if n := server.refreshes.Load(); n != 1 {
+		t.Errorf("token refreshed %d times, want 1", n)
+	}
+}
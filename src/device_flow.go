@@ -0,0 +1,215 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// deviceCodeGrantType is the OAuth 2.0 device authorization grant (RFC 8628)
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultDevicePollInterval is used when the server does not specify one
+const defaultDevicePollInterval = 5 * time.Second
+
+// slowDownIncrement is added to the poll interval on each slow_down response.
+// It is a variable so tests can shorten it.
+var slowDownIncrement = 5 * time.Second
+
+var (
+	// ErrDeviceCodeExpired is returned when the user did not approve the device code in time
+	ErrDeviceCodeExpired = errors.New("tavo: device code expired before it was approved")
+
+	// ErrDeviceAccessDenied is returned when the user rejected the authorization request
+	ErrDeviceAccessDenied = errors.New("tavo: device authorization was denied")
+)
+
+// DeviceFlow runs the OAuth device authorization flow: it requests a device code,
+// hands the user code to Prompt, polls for the token and installs it on the client.
+type DeviceFlow struct {
+	client *Client
+
+	// Client identifier sent with the code request
+	ClientID string
+
+	// Human readable client name shown to the user while approving
+	ClientName string
+
+	// Request the code through the CLI-specific /code/cli endpoint
+	CLI bool
+
+	// Prompt is called once the user code and verification URL are known.
+	// Returning an error aborts the flow.
+	Prompt func(code *DeviceCode) error
+
+	// Poll interval used when the server does not provide one
+	Interval time.Duration
+}
+
+// NewDeviceFlow creates a device authorization flow for client
+func NewDeviceFlow(client *Client) *DeviceFlow {
+	return &DeviceFlow{client: client, Interval: defaultDevicePollInterval}
+}
+
+// Run performs the whole flow and returns the issued token, which is also
+// installed on the client and saved to its credential store, if any
+func (f *DeviceFlow) Run(ctx context.Context) (*DeviceToken, error) {
+	code, err := f.RequestCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if f.Prompt != nil {
+		if err := f.Prompt(code); err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := f.Poll(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.client.installDeviceToken(token); err != nil {
+		return token, fmt.Errorf("save device token: %w", err)
+	}
+	return token, nil
+}
+
+// RequestCode starts the flow by requesting a device and user code
+func (f *DeviceFlow) RequestCode(ctx context.Context) (*DeviceCode, error) {
+	var clientName *string
+	if f.ClientName != "" {
+		clientName = &f.ClientName
+	}
+
+	var result interface{}
+	var err error
+	if f.CLI {
+		result, err = f.client.deviceAuth.PostcodecliWithContext(ctx, clientName)
+	} else {
+		var clientID *string
+		if f.ClientID != "" {
+			clientID = &f.ClientID
+		}
+		result, err = f.client.deviceAuth.PostcodeWithContext(ctx, clientID, clientName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var code DeviceCode
+	if err := decodeResult(result, &code); err != nil {
+		return nil, fmt.Errorf("decode device code: %w", err)
+	}
+	if code.DeviceCode == "" {
+		return nil, errors.New("tavo: device code response did not include a device_code")
+	}
+	return &code, nil
+}
+
+// Poll waits for the user to approve code, honoring the server's poll interval
+// and slow_down requests, until a token is issued, the code expires or ctx is done
+func (f *DeviceFlow) Poll(ctx context.Context, code *DeviceCode) (*DeviceToken, error) {
+	interval := f.Interval
+	if code.Interval > 0 {
+		interval = time.Duration(code.Interval) * time.Second
+	}
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	parent := ctx
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	// expired reports whether ctx ended because the code expired rather than
+	// because the caller's context is done
+	expired := func() bool {
+		return code.ExpiresIn > 0 && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
+	}
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			if expired() {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, err
+		}
+
+		token, err := f.requestToken(ctx, code.DeviceCode)
+		if err == nil {
+			return token, nil
+		}
+		if expired() {
+			return nil, ErrDeviceCodeExpired
+		}
+
+		switch oauthErrorCode(err) {
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrDeviceAccessDenied
+		default:
+			if IsRateLimited(err) {
+				interval += slowDownIncrement
+				continue
+			}
+			return nil, err
+		}
+	}
+}
+
+// requestToken exchanges an approved device code for a token
+func (f *DeviceFlow) requestToken(ctx context.Context, deviceCode string) (*DeviceToken, error) {
+	body := map[string]interface{}{
+		"grant_type":  deviceCodeGrantType,
+		"device_code": deviceCode,
+	}
+	if f.ClientID != "" {
+		body["client_id"] = f.ClientID
+	}
+
+	var token DeviceToken
+	if err := f.client.do(ctx, http.MethodPost, "/token", nil, body, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("tavo: token response did not include an access_token")
+	}
+	return &token, nil
+}
+
+// oauthErrorCode extracts the OAuth error code (e.g. "authorization_pending")
+// from an API error, looking at both the top-level and FastAPI "detail" shapes
+func oauthErrorCode(err error) string {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return ""
+	}
+	if apiErr.Response.Error != "" {
+		return apiErr.Response.Error
+	}
+
+	var payload struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(apiErr.Body, &payload) != nil || len(payload.Detail) == 0 {
+		return ""
+	}
+	var detail string
+	if json.Unmarshal(payload.Detail, &detail) == nil {
+		return detail
+	}
+	var nested ErrorResponse
+	if json.Unmarshal(payload.Detail, &nested) == nil {
+		return nested.Error
+	}
+	return ""
+}
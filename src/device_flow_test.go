@@ -0,0 +1,187 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deviceServer issues a device code and answers token polls with responses in order,
+// repeating the last one
+type deviceServer struct {
+	code      string
+	responses []deviceResponse
+
+	mu    sync.Mutex
+	polls []time.Time
+	body  map[string]interface{}
+}
+
+type deviceResponse struct {
+	status int
+	body   string
+}
+
+func (s *deviceServer) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/token") {
+		w.Write([]byte(s.code))
+		return
+	}
+
+	s.mu.Lock()
+	json.NewDecoder(r.Body).Decode(&s.body)
+	s.polls = append(s.polls, time.Now())
+	response := s.responses[min(len(s.polls), len(s.responses))-1]
+	s.mu.Unlock()
+
+	w.WriteHeader(response.status)
+	w.Write([]byte(response.body))
+}
+
+// pollTimes returns when each token request arrived
+func (s *deviceServer) pollTimes() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.polls...)
+}
+
+// shortSlowDown shrinks slowDownIncrement for the duration of the test
+func shortSlowDown(t *testing.T, increment time.Duration) {
+	previous := slowDownIncrement
+	slowDownIncrement = increment
+	t.Cleanup(func() { slowDownIncrement = previous })
+}
+
+var (
+	pending     = deviceResponse{http.StatusBadRequest, `{"error": "authorization_pending"}`}
+	slowDown    = deviceResponse{http.StatusBadRequest, `{"detail": "slow_down"}`}
+	rateLimited = deviceResponse{http.StatusTooManyRequests, `{"error": "too_many_requests"}`}
+	issued      = deviceResponse{http.StatusOK, `{"access_token": "device-token", "refresh_token": "refresh", "expires_in": 3600}`}
+)
+
+func TestDeviceFlowPoll(t *testing.T) {
+	shortSlowDown(t, 50*time.Millisecond)
+
+	tests := []struct {
+		name      string
+		responses []deviceResponse
+		wantPolls int
+		wantErr   error
+		// index of a poll that must come at least slowDownIncrement after the previous one
+		slowPoll int
+	}{
+		{"approved", []deviceResponse{issued}, 1, nil, 0},
+		{"authorization pending", []deviceResponse{pending, pending, issued}, 3, nil, 0},
+		{"slow down", []deviceResponse{pending, slowDown, issued}, 3, nil, 2},
+		{"rate limited", []deviceResponse{rateLimited, issued}, 2, nil, 1},
+		{"expired token", []deviceResponse{pending, {http.StatusBadRequest, `{"error": "expired_token"}`}}, 2, ErrDeviceCodeExpired, 0},
+		{"access denied", []deviceResponse{{http.StatusBadRequest, `{"detail": {"error": "access_denied"}}`}}, 1, ErrDeviceAccessDenied, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &deviceServer{responses: tt.responses}
+			client := newTestClient(t, server.serve, WithRetryPolicy(NoRetries()))
+			flow := NewDeviceFlow(client)
+			flow.Interval = time.Millisecond
+			flow.ClientID = "cli"
+
+			token, err := flow.Poll(context.Background(), &DeviceCode{DeviceCode: "dc-1", ExpiresIn: 60})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Poll = %+v, %v; want %v", token, err, tt.wantErr)
+				}
+			} else if err != nil || token.AccessToken != "device-token" || token.RefreshToken != "refresh" {
+				t.Fatalf("Poll = %+v, %v; want the issued token", token, err)
+			}
+
+			polls := server.pollTimes()
+			if len(polls) != tt.wantPolls {
+				t.Errorf("polled %d times, want %d", len(polls), tt.wantPolls)
+			}
+			if tt.slowPoll > 0 {
+				if gap := polls[tt.slowPoll].Sub(polls[tt.slowPoll-1]); gap < slowDownIncrement {
+					t.Errorf("poll %d came %v after the previous one, want at least %v", tt.slowPoll, gap, slowDownIncrement)
+				}
+			}
+			if server.body["grant_type"] != deviceCodeGrantType || server.body["device_code"] != "dc-1" || server.body["client_id"] != "cli" {
+				t.Errorf("token request = %v", server.body)
+			}
+		})
+	}
+}
+
+func TestDeviceFlowPollStops(t *testing.T) {
+	// newFlow polls a server that always answers with response
+	newFlow := func(response deviceResponse) *DeviceFlow {
+		server := &deviceServer{responses: []deviceResponse{response}}
+		flow := NewDeviceFlow(newTestClient(t, server.serve, WithRetryPolicy(NoRetries())))
+		flow.Interval = time.Millisecond
+		return flow
+	}
+
+	if _, err := newFlow(pending).Poll(context.Background(), &DeviceCode{DeviceCode: "dc-1", ExpiresIn: 1}); !errors.Is(err, ErrDeviceCodeExpired) {
+		t.Errorf("Poll past expires_in = %v, want ErrDeviceCodeExpired", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := newFlow(pending).Poll(ctx, &DeviceCode{DeviceCode: "dc-1", ExpiresIn: 60}); !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrDeviceCodeExpired) {
+		t.Errorf("Poll with an expired caller context = %v, want context.DeadlineExceeded", err)
+	}
+
+	failure := deviceResponse{http.StatusInternalServerError, `{"error": "internal"}`}
+	if _, err := newFlow(failure).Poll(context.Background(), &DeviceCode{DeviceCode: "dc-1"}); !IsServerError(err) {
+		t.Errorf("Poll = %v, want the server error", err)
+	}
+}
+
+func TestDeviceFlowRun(t *testing.T) {
+	server := &deviceServer{
+		code:      `{"device_code": "dc-1", "user_code": "ABCD-EFGH", "verification_uri": "https://tavo.ai/device", "expires_in": 60}`,
+		responses: []deviceResponse{pending, issued},
+	}
+	store := NewMemoryCredentialStore()
+	client := newTestClient(t, server.serve, WithRetryPolicy(NoRetries()), WithCredentialStore(store, "ci"))
+	flow := NewDeviceFlow(client)
+	flow.Interval = time.Millisecond
+
+	var prompted *DeviceCode
+	flow.Prompt = func(code *DeviceCode) error {
+		prompted = code
+		return nil
+	}
+	if _, err := flow.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if prompted == nil || prompted.UserCode != "ABCD-EFGH" || prompted.VerificationURI != "https://tavo.ai/device" {
+		t.Errorf("Prompt got %+v", prompted)
+	}
+
+	creds := client.Credentials()
+	if creds.APIKey != "" || creds.DeviceToken != "device-token" || creds.RefreshToken != "refresh" || time.Until(creds.ExpiresAt) < 59*time.Minute {
+		t.Errorf("client credentials = %+v, want the device token", creds)
+	}
+	saved, err := store.Load("ci", client.baseURL)
+	if err != nil || saved.DeviceToken != "device-token" || saved.RefreshToken != "refresh" {
+		t.Errorf("stored credentials = %+v, %v", saved, err)
+	}
+
+	server = &deviceServer{code: server.code, responses: []deviceResponse{issued}}
+	flow = NewDeviceFlow(newTestClient(t, server.serve, WithRetryPolicy(NoRetries())))
+	aborted := errors.New("user cancelled")
+	flow.Prompt = func(code *DeviceCode) error { return aborted }
+	if _, err := flow.Run(context.Background()); !errors.Is(err, aborted) || len(server.pollTimes()) != 0 {
+		t.Errorf("Run with a failing prompt = %v after %d polls", err, len(server.pollTimes()))
+	}
+
+	server = &deviceServer{code: `{"user_code": "ABCD-EFGH"}`}
+	flow = NewDeviceFlow(newTestClient(t, server.serve, WithRetryPolicy(NoRetries())))
+	if _, err := flow.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "device_code") {
+		t.Errorf("Run without a device_code = %v", err)
+	}
+}
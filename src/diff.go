@@ -0,0 +1,485 @@
+package tavo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeType is how a file changed between two refs
+type ChangeType string
+
+const (
+	ChangeAdded       ChangeType = "added"
+	ChangeModified    ChangeType = "modified"
+	ChangeRenamed     ChangeType = "renamed"
+	ChangeCopied      ChangeType = "copied"
+	ChangeDeleted     ChangeType = "deleted"
+	ChangeTypeChanged ChangeType = "type_changed"
+)
+
+// changeTypeByStatus maps git's --name-status letters to change types
+var changeTypeByStatus = map[byte]ChangeType{
+	'A': ChangeAdded,
+	'M': ChangeModified,
+	'R': ChangeRenamed,
+	'C': ChangeCopied,
+	'D': ChangeDeleted,
+	'T': ChangeTypeChanged,
+}
+
+// LineRange is an inclusive range of 1-based line numbers
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// overlaps reports whether the ranges share at least one line
+func (r LineRange) overlaps(other LineRange) bool {
+	return r.Start <= other.End && other.Start <= r.End
+}
+
+// DiffHunk is one hunk of a unified diff without context lines
+type DiffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+}
+
+// NewRange returns the lines of the hunk in the head version of the file. It
+// is empty (End < Start) for a hunk that only deletes lines.
+func (h DiffHunk) NewRange() LineRange {
+	return LineRange{Start: h.NewStart, End: h.NewStart + h.NewLines - 1}
+}
+
+// FileChange is a file that differs between the base and head refs
+type FileChange struct {
+	Path         string
+	PreviousPath string
+	Type         ChangeType
+	Hunks        []DiffHunk
+}
+
+// ChangedLines returns the line ranges added or modified in the head version
+func (f *FileChange) ChangedLines() []LineRange {
+	var ranges []LineRange
+	for _, hunk := range f.Hunks {
+		if hunk.NewLines > 0 {
+			ranges = append(ranges, hunk.NewRange())
+		}
+	}
+	return ranges
+}
+
+// DiffSubmitOptions configures SubmitDiff
+type DiffSubmitOptions struct {
+	SubmitOptions
+
+	// Ref the changes are compared against, e.g. "origin/main". Changes are
+	// taken from the merge base of BaseRef and HeadRef, like "git diff base...head".
+	BaseRef string
+
+	// Ref holding the changes; defaults to HEAD. File content is read from this
+	// ref, so uncommitted changes are not submitted.
+	HeadRef string
+}
+
+// DiffSubmission describes what SubmitDiff sent
+type DiffSubmission struct {
+	DirectorySubmission
+
+	BaseRef    string
+	HeadRef    string
+	BaseCommit string
+	HeadCommit string
+
+	// Every changed file, including deleted and excluded ones
+	Changes []FileChange
+}
+
+// DiffFinding is a finding placed relative to the diff of a DiffSubmission
+type DiffFinding struct {
+	Finding Finding
+
+	// Changed file the finding belongs to; nil if the file is not part of the diff
+	Change *FileChange
+
+	// Hunk the finding overlaps; nil if it lies outside the changed lines
+	Hunk *DiffHunk
+
+	// Whether the finding was introduced by the changes rather than pre-existing
+	Introduced bool
+}
+
+// SubmitDiff submits only the files changed between two refs of the git
+// repository at repoDir, with the changed line ranges of each file as hints.
+// Use MapFindings on the result to separate new findings from existing ones.
+func (c *CodeSubmissionClient) SubmitDiff(ctx context.Context, repoDir string, opts *DiffSubmitOptions) (*DiffSubmission, error) {
+	if opts == nil || opts.BaseRef == "" {
+		return nil, errors.New("tavo: SubmitDiff requires a base ref")
+	}
+	headRef := opts.HeadRef
+	if headRef == "" {
+		headRef = "HEAD"
+	}
+	options := opts.SubmitOptions.withDefaults()
+
+	submission := &DiffSubmission{BaseRef: opts.BaseRef, HeadRef: headRef}
+	var err error
+	if submission.HeadCommit, err = runGit(ctx, repoDir, "rev-parse", "--verify", headRef+"^{commit}"); err != nil {
+		return nil, err
+	}
+	if submission.BaseCommit, err = runGit(ctx, repoDir, "merge-base", opts.BaseRef, submission.HeadCommit); err != nil {
+		return nil, err
+	}
+	if submission.Changes, err = diffChanges(ctx, repoDir, submission.BaseCommit, submission.HeadCommit); err != nil {
+		return nil, err
+	}
+
+	files, skipped, err := collectChangedFiles(ctx, repoDir, submission.HeadCommit, submission.Changes, options)
+	if err != nil {
+		return nil, err
+	}
+	submission.Skipped = skipped
+
+	if options.CommitSHA == "" {
+		options.CommitSHA = submission.HeadCommit
+	}
+	if options.Branch == "" && headRef != "HEAD" {
+		options.Branch = strings.TrimPrefix(headRef, "refs/heads/")
+	}
+	if err := c.describeRepository(ctx, repoDir, options, &submission.DirectorySubmission); err != nil {
+		return nil, err
+	}
+
+	scanConfig := make(map[string]interface{}, len(options.ScanConfig)+1)
+	for key, value := range options.ScanConfig {
+		scanConfig[key] = value
+	}
+	scanConfig["diff"] = map[string]interface{}{
+		"base_ref":    submission.BaseRef,
+		"head_ref":    submission.HeadRef,
+		"base_commit": submission.BaseCommit,
+		"head_commit": submission.HeadCommit,
+	}
+	options.ScanConfig = scanConfig
+
+	return submission, c.submitFiles(ctx, files, options, &submission.DirectorySubmission)
+}
+
+// MapFindings places findings relative to the diff. A finding is introduced
+// when it is in an added file or overlaps a changed hunk; findings without a
+// line number count as introduced only in added files.
+func (s *DiffSubmission) MapFindings(findings []Finding) []DiffFinding {
+	byPath := make(map[string]*FileChange, len(s.Changes))
+	for i := range s.Changes {
+		byPath[s.Changes[i].Path] = &s.Changes[i]
+	}
+
+	mapped := make([]DiffFinding, 0, len(findings))
+	for _, finding := range findings {
+		entry := DiffFinding{Finding: finding, Change: s.lookupChange(byPath, finding.FilePath)}
+		if entry.Change != nil {
+			entry.Hunk = findingHunk(entry.Change, finding)
+			entry.Introduced = entry.Change.Type == ChangeAdded || entry.Hunk != nil
+		}
+		mapped = append(mapped, entry)
+	}
+	return mapped
+}
+
+// IntroducedFindings returns the findings introduced by the changes
+func (s *DiffSubmission) IntroducedFindings(findings []Finding) []Finding {
+	var introduced []Finding
+	for _, entry := range s.MapFindings(findings) {
+		if entry.Introduced {
+			introduced = append(introduced, entry.Finding)
+		}
+	}
+	return introduced
+}
+
+// lookupChange finds the change for a finding's file path, which scanners may
+// report relative to the repository, with a "./" prefix or as an absolute path.
+// When several changed paths are suffixes of an absolute path the longest wins.
+func (s *DiffSubmission) lookupChange(byPath map[string]*FileChange, filePath string) *FileChange {
+	if filePath == "" {
+		return nil
+	}
+	cleaned := strings.TrimPrefix(path.Clean(filepath.ToSlash(filePath)), "./")
+	if change, ok := byPath[cleaned]; ok {
+		return change
+	}
+	var match *FileChange
+	matched := ""
+	for changed, change := range byPath {
+		if !strings.HasSuffix(cleaned, "/"+changed) {
+			continue
+		}
+		if len(changed) > len(matched) {
+			match, matched = change, changed
+		}
+	}
+	return match
+}
+
+// findingHunk returns the hunk of change that a finding's lines overlap
+func findingHunk(change *FileChange, finding Finding) *DiffHunk {
+	if finding.LineNumber <= 0 {
+		return nil
+	}
+	lines := LineRange{Start: finding.LineNumber, End: max(finding.EndLine, finding.LineNumber)}
+	for i, hunk := range change.Hunks {
+		if hunk.NewLines > 0 && hunk.NewRange().overlaps(lines) {
+			return &change.Hunks[i]
+		}
+	}
+	return nil
+}
+
+// collectChangedFiles reads the head version of every changed file that passes
+// the options' filters and the repository's .tavoignore files
+func collectChangedFiles(ctx context.Context, repoDir, headCommit string, changes []FileChange, options *SubmitOptions) ([]SourceFile, []SkippedFile, error) {
+	toplevel, err := runGit(ctx, repoDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, nil, err
+	}
+	ignores, err := treeIgnoreRules(ctx, toplevel, headCommit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []SourceFile
+	var skipped []SkippedFile
+	for i := range changes {
+		change := &changes[i]
+		if change.Type == ChangeDeleted || matchAnyGlob(options.Exclude, change.Path) {
+			continue
+		}
+		if len(options.Include) > 0 && !matchAnyGlob(options.Include, change.Path) {
+			continue
+		}
+		if ignores.ignoredPath(change.Path) {
+			continue
+		}
+
+		size, err := runGit(ctx, toplevel, "cat-file", "-s", headCommit+":"+change.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if n, _ := strconv.ParseInt(size, 10, 64); n > options.MaxFileSize {
+			skipped = append(skipped, SkippedFile{Path: change.Path, Reason: SkipReasonTooLarge})
+			continue
+		}
+		content, err := gitOutput(ctx, toplevel, "cat-file", "blob", headCommit+":"+change.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !isText(content) {
+			skipped = append(skipped, SkippedFile{Path: change.Path, Reason: SkipReasonBinary})
+			continue
+		}
+
+		files = append(files, SourceFile{
+			Path:         change.Path,
+			Content:      string(content),
+			Language:     DetectLanguage(change.Path),
+			Size:         int64(len(content)),
+			ChangedLines: change.ChangedLines(),
+			PreviousPath: change.PreviousPath,
+		})
+	}
+	return files, skipped, nil
+}
+
+// treeIgnoreRules loads the .tavoignore files of a commit. .gitignore files are
+// not consulted since every changed file is already tracked.
+func treeIgnoreRules(ctx context.Context, dir, commit string) (ignoreRules, error) {
+	listing, err := gitOutput(ctx, dir, "ls-tree", "-r", "-z", "--name-only", commit)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, name := range strings.Split(string(listing), "\x00") {
+		if path.Base(name) == ".tavoignore" {
+			paths = append(paths, name)
+		}
+	}
+	// Parents before children, so deeper files take precedence
+	sort.SliceStable(paths, func(i, j int) bool {
+		return strings.Count(paths[i], "/") < strings.Count(paths[j], "/")
+	})
+
+	var rules ignoreRules
+	for _, name := range paths {
+		content, err := gitOutput(ctx, dir, "cat-file", "blob", commit+":"+name)
+		if err != nil {
+			return nil, err
+		}
+		base := path.Dir(name)
+		if base == "." {
+			base = ""
+		}
+		if err := rules.parse(bytes.NewReader(content), base); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// diffChanges lists the files changed between two commits along with their hunks
+func diffChanges(ctx context.Context, dir, base, head string) ([]FileChange, error) {
+	status, err := gitOutput(ctx, dir, "diff", "--no-color", "--no-ext-diff", "-M", "-z", "--name-status", base, head)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := parseNameStatus(string(status))
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := gitOutput(ctx, dir, "-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff", "-M", "-U0",
+		"--src-prefix=a/", "--dst-prefix=b/", base, head)
+	if err != nil {
+		return nil, err
+	}
+	hunks, err := parseHunks(string(patch))
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		changes[i].Hunks = hunks[changes[i].Path]
+	}
+	return changes, nil
+}
+
+// parseNameStatus parses the output of git diff --name-status -z
+func parseNameStatus(output string) ([]FileChange, error) {
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	var changes []FileChange
+	for i := 0; i < len(fields) && fields[i] != ""; {
+		status := fields[i]
+		changeType, ok := changeTypeByStatus[status[0]]
+		if !ok {
+			return nil, fmt.Errorf("tavo: unexpected git diff status %q", status)
+		}
+		change := FileChange{Type: changeType}
+		if changeType == ChangeRenamed || changeType == ChangeCopied {
+			if i+2 >= len(fields) {
+				return nil, errors.New("tavo: truncated git diff output")
+			}
+			change.PreviousPath, change.Path = fields[i+1], fields[i+2]
+			i += 3
+		} else {
+			if i+1 >= len(fields) {
+				return nil, errors.New("tavo: truncated git diff output")
+			}
+			change.Path = fields[i+1]
+			i += 2
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// parseHunks parses a unified diff with zero context lines into hunks keyed by
+// the file's path in the head version. Hunk bodies are skipped using the line
+// counts in their headers, so added or removed lines that look like file
+// headers are not mistaken for them.
+func parseHunks(patch string) (map[string][]DiffHunk, error) {
+	hunks := make(map[string][]DiffHunk)
+	current := ""
+	oldLeft, newLeft := 0, 0
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+				continue
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+				continue
+			case strings.HasPrefix(line, " ") || line == "":
+				oldLeft--
+				newLeft--
+				continue
+			case strings.HasPrefix(line, "\\"):
+				// "\ No newline at end of file"
+				continue
+			}
+			return nil, fmt.Errorf("tavo: hunk body ends early at %q", line)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = ""
+		case strings.HasPrefix(line, "+++ "):
+			// git appends a tab to names containing spaces
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t")
+			if unquoted, err := strconv.Unquote(name); err == nil {
+				name = unquoted
+			}
+			current = strings.TrimPrefix(name, "b/")
+			if name == "/dev/null" {
+				current = ""
+			}
+		case strings.HasPrefix(line, "@@ "):
+			hunk, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+			if current != "" {
+				hunks[current] = append(hunks[current], hunk)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if oldLeft > 0 || newLeft > 0 {
+		return nil, errors.New("tavo: truncated hunk at the end of the diff")
+	}
+	return hunks, nil
+}
+
+// parseHunkHeader parses a line such as "@@ -10,2 +12,3 @@ func main() {"
+func parseHunkHeader(line string) (DiffHunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return DiffHunk{}, fmt.Errorf("tavo: malformed hunk header %q", line)
+	}
+	var hunk DiffHunk
+	var err error
+	if hunk.OldStart, hunk.OldLines, err = parseHunkRange(fields[1][1:]); err != nil {
+		return DiffHunk{}, fmt.Errorf("tavo: malformed hunk header %q: %w", line, err)
+	}
+	if hunk.NewStart, hunk.NewLines, err = parseHunkRange(fields[2][1:]); err != nil {
+		return DiffHunk{}, fmt.Errorf("tavo: malformed hunk header %q: %w", line, err)
+	}
+	return hunk, nil
+}
+
+// parseHunkRange parses "start,count" or "start", where count defaults to 1
+func parseHunkRange(value string) (start, count int, err error) {
+	startText, countText, found := strings.Cut(value, ",")
+	if start, err = strconv.Atoi(startText); err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return start, 1, nil
+	}
+	count, err = strconv.Atoi(countText)
+	return start, count, err
+}
@@ -0,0 +1,207 @@
+package tavo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseHunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  map[string][]DiffHunk
+	}{
+		{
+			name: "modified file",
+			patch: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3 +3,2 @@ func main() {
+-	old()
++	first()
++	second()
+@@ -10,0 +12 @@ func helper() {
++	added()
+`,
+			want: map[string][]DiffHunk{"main.go": {{3, 1, 3, 2}, {10, 0, 12, 1}}},
+		},
+		{
+			name: "added lines that look like file headers",
+			patch: `diff --git a/notes.md b/notes.md
+index 1111111..2222222 100644
+--- a/notes.md
++++ b/notes.md
+@@ -1,0 +2,3 @@
++++ b/fake.go
++--- a/fake.go
++diff --git a/fake.go b/fake.go
+@@ -5,2 +8 @@
+--- removed
+--- a/other.go
++@@ -1 +1 @@
+`,
+			want: map[string][]DiffHunk{"notes.md": {{1, 0, 2, 3}, {5, 2, 8, 1}}},
+		},
+		{
+			name: "new and deleted files",
+			patch: `diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..2222222
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package main
++
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 1111111..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package gone
+`,
+			want: map[string][]DiffHunk{"new.go": {{0, 0, 1, 2}}},
+		},
+		{
+			name: "quoted name and missing newline",
+			patch: `diff --git "a/with space.go" "b/with space.go"
+--- "a/with space.go"
++++ "b/with space.go"
+@@ -1 +1 @@
+-a
+\ No newline at end of file
++b
+\ No newline at end of file
+`,
+			want: map[string][]DiffHunk{"with space.go": {{1, 1, 1, 1}}},
+		},
+		{
+			name: "context lines",
+			patch: `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,3 +1,3 @@
+ one
+-two
++TWO
+
+`,
+			want: map[string][]DiffHunk{"a.txt": {{1, 3, 1, 3}}},
+		},
+		{
+			name: "binary file",
+			patch: `diff --git a/img.png b/img.png
+index 1111111..2222222 100644
+Binary files a/img.png and b/img.png differ
+`,
+			want: map[string][]DiffHunk{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHunks(tt.patch)
+			if err != nil {
+				t.Fatalf("parseHunks: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHunks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHunksErrors(t *testing.T) {
+	for name, patch := range map[string]string{
+		"malformed header": "+++ b/a.go\n@@ -x +1 @@\n+a\n",
+		"truncated body":   "+++ b/a.go\n@@ -1 +1,3 @@\n-a\n+b\n",
+		"unexpected line":  "+++ b/a.go\n@@ -1 +1,2 @@\n+a\ndiff --git a/b.go b/b.go\n",
+	} {
+		if _, err := parseHunks(patch); err == nil {
+			t.Errorf("%s: parseHunks succeeded", name)
+		}
+	}
+}
+
+func TestDiffChangesWithHeaderLikeLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("title\n-- signature\nend\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "base")
+	write("title\n++ b/added.md\nend\n")
+	git("commit", "-q", "-am", "head")
+
+	changes, err := diffChanges(context.Background(), dir, "HEAD~1", "HEAD")
+	if err != nil {
+		t.Fatalf("diffChanges: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != "notes.md" {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if want := []DiffHunk{{2, 1, 2, 1}}; !reflect.DeepEqual(changes[0].Hunks, want) {
+		t.Errorf("hunks = %v, want %v", changes[0].Hunks, want)
+	}
+}
+
+func TestMapFindingsMatchesLongestPathSuffix(t *testing.T) {
+	submission := &DiffSubmission{Changes: []FileChange{
+		{Path: "main.go", Type: ChangeModified, Hunks: []DiffHunk{{1, 1, 1, 1}}},
+		{Path: "cmd/main.go", Type: ChangeModified, Hunks: []DiffHunk{{10, 1, 10, 1}}},
+		{Path: "tools/cmd/main.go", Type: ChangeAdded},
+		{Path: "web/app.ts", Type: ChangeModified},
+	}}
+	tests := []struct {
+		filePath string
+		want     string
+	}{
+		{"main.go", "main.go"},
+		{"./cmd/main.go", "cmd/main.go"},
+		{"/src/repo/cmd/main.go", "cmd/main.go"},
+		{"/src/repo/tools/cmd/main.go", "tools/cmd/main.go"},
+		{"/src/repo/main.go", "main.go"},
+		{"/src/repo/xcmd/main.go", "main.go"},
+		{"/src/repo/web/other.ts", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		// Map iteration order varies, so repeat to catch a random pick
+		for range 20 {
+			mapped := submission.MapFindings([]Finding{{FilePath: tt.filePath, LineNumber: 10}})
+			got := ""
+			if mapped[0].Change != nil {
+				got = mapped[0].Change.Path
+			}
+			if got != tt.want {
+				t.Fatalf("change for %q = %q, want %q", tt.filePath, got, tt.want)
+			}
+		}
+	}
+
+	mapped := submission.MapFindings([]Finding{{FilePath: "/src/repo/cmd/main.go", LineNumber: 10}})
+	if !mapped[0].Introduced || mapped[0].Hunk == nil || mapped[0].Hunk.NewStart != 10 {
+		t.Errorf("mapped = %+v, want the finding in the cmd/main.go hunk", mapped[0])
+	}
+}
@@ -0,0 +1,474 @@
+package tavo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when downloaded content does not match its SHA-256 checksum
+var ErrChecksumMismatch = errors.New("tavo: download checksum mismatch")
+
+// errContentChanged is returned when a resumed download's content was replaced
+// on the server since the first part was received
+var errContentChanged = errors.New("tavo: content changed on the server while resuming the download")
+
+// defaultMaxResumes is how often an interrupted download is resumed when no limit is set
+const defaultMaxResumes = 3
+
+// downloadBufferSize is the size of the buffer used to copy downloads
+const downloadBufferSize = 32 * 1024
+
+// DownloadOptions configures a download
+type DownloadOptions struct {
+	// Expected SHA-256 of the content as a hex string. When empty, the checksum
+	// sent by the server is used, if any.
+	SHA256 string
+
+	// Fail when no checksum is available to verify the download against
+	RequireChecksum bool
+
+	// How often an interrupted transfer is resumed with a Range request; zero
+	// uses the default and a negative value disables resuming
+	MaxResumes int
+
+	// OnProgress is called as data arrives with the bytes received so far and
+	// the total size, or -1 if the server did not report it
+	OnProgress func(received, total int64)
+}
+
+// DownloadResult describes a completed download
+type DownloadResult struct {
+	// Name suggested by the server's Content-Disposition header
+	Filename    string
+	ContentType string
+
+	Size   int64
+	SHA256 string
+
+	// Whether the content was checked against an expected checksum
+	Verified bool
+
+	// Number of times the transfer was resumed after an interruption
+	Resumes int
+}
+
+// downloadState tracks a download across resumed requests
+type downloadState struct {
+	written  int64
+	total    int64
+	hash     hash.Hash
+	checksum string
+	etag     string
+	result   DownloadResult
+
+	// Called with the ETag of each response that has one, if set
+	saveETag func(etag string) error
+}
+
+// newDownloadState starts a download at offset, with hash already fed the first offset bytes
+func newDownloadState(offset int64, h hash.Hash) *downloadState {
+	if h == nil {
+		h = sha256.New()
+	}
+	return &downloadState{written: offset, total: -1, hash: h}
+}
+
+// download streams the response of a GET request into w, resuming interrupted
+// transfers with Range requests and verifying the SHA-256 checksum at the end
+func (c *Client) download(ctx context.Context, path string, query url.Values, w io.Writer, state *downloadState, opts *DownloadOptions) (*DownloadResult, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	maxResumes := opts.MaxResumes
+	if maxResumes == 0 {
+		maxResumes = defaultMaxResumes
+	}
+
+	for {
+		err := c.downloadOnce(ctx, path, query, w, state, opts)
+		if err == nil {
+			break
+		}
+		var transferErr *transferError
+		if !errors.As(err, &transferErr) || ctx.Err() != nil || state.result.Resumes >= maxResumes {
+			return nil, err
+		}
+		state.result.Resumes++
+		if err := sleep(ctx, c.policy().backoff(state.result.Resumes)); err != nil {
+			return nil, err
+		}
+	}
+
+	if state.total >= 0 && state.written != state.total {
+		return nil, fmt.Errorf("tavo: download incomplete: received %d of %d bytes", state.written, state.total)
+	}
+	result := state.result
+	result.Size = state.written
+	result.SHA256 = hex.EncodeToString(state.hash.Sum(nil))
+
+	expected := strings.ToLower(strings.TrimSpace(opts.SHA256))
+	if expected == "" {
+		expected = state.checksum
+	}
+	switch {
+	case expected != "" && expected != result.SHA256:
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, result.SHA256)
+	case expected != "":
+		result.Verified = true
+	case opts.RequireChecksum:
+		return nil, errors.New("tavo: no checksum available to verify the download")
+	}
+	return &result, nil
+}
+
+// transferError is a failure while reading a response body, after which the
+// download can be resumed
+type transferError struct {
+	err error
+}
+
+func (e *transferError) Error() string { return "tavo: download interrupted: " + e.err.Error() }
+func (e *transferError) Unwrap() error { return e.err }
+
+// downloadOnce sends one request for the rest of the content and copies the body into w
+func (c *Client) downloadOnce(ctx context.Context, path string, query url.Values, w io.Writer, state *downloadState, opts *DownloadOptions) error {
+	reqCtx := ctx
+	if state.written > 0 {
+		reqCtx = withRequestHeader(reqCtx, "Range", "bytes="+strconv.FormatInt(state.written, 10)+"-")
+		if state.etag != "" {
+			reqCtx = withRequestHeader(reqCtx, "If-Range", state.etag)
+		}
+	}
+
+	resp, err := c.send(reqCtx, "GET", path, query, nil)
+	if err != nil {
+		if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable && state.written > 0 {
+			// Everything was received before the interruption
+			if state.total < 0 {
+				state.total = state.written
+			}
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	skip := int64(0)
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start > state.written {
+			return fmt.Errorf("tavo: unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+		skip = state.written - start
+		if total >= 0 {
+			state.total = total
+		}
+	default:
+		// The server sent the whole content. If-Range makes it do so when the
+		// content has changed, in which case the part received is stale.
+		if state.written > 0 && state.etag != "" && resp.Header.Get("ETag") != state.etag {
+			return errContentChanged
+		}
+		skip = state.written
+		if resp.ContentLength >= 0 {
+			state.total = resp.ContentLength
+		}
+	}
+	state.readHeaders(resp)
+	if state.saveETag != nil && state.etag != "" {
+		if err := state.saveETag(state.etag); err != nil {
+			return err
+		}
+	}
+
+	if skip > 0 {
+		if _, err := io.CopyN(io.Discard, resp.Body, skip); err != nil {
+			return &transferError{err: err}
+		}
+	}
+
+	buf := make([]byte, downloadBufferSize)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+			state.hash.Write(buf[:n])
+			state.written += int64(n)
+			if opts.OnProgress != nil {
+				opts.OnProgress(state.written, state.total)
+			}
+		}
+		if readErr == io.EOF {
+			if state.total >= 0 && state.written < state.total {
+				return &transferError{err: io.ErrUnexpectedEOF}
+			}
+			return nil
+		}
+		if readErr != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return &transferError{err: readErr}
+		}
+	}
+}
+
+// readHeaders records the metadata of the first response that carries it
+func (s *downloadState) readHeaders(resp *http.Response) {
+	if s.checksum == "" {
+		s.checksum = responseChecksum(resp.Header)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		s.etag = etag
+	}
+	if s.result.ContentType == "" {
+		s.result.ContentType = resp.Header.Get("Content-Type")
+	}
+	if s.result.Filename == "" {
+		if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+			s.result.Filename = params["filename"]
+		}
+	}
+}
+
+// responseChecksum returns the SHA-256 of the full content announced in the
+// response headers as a lower-case hex string, or "" if there is none
+func responseChecksum(header http.Header) string {
+	for _, name := range []string{"X-Checksum-SHA256", "X-Content-SHA256"} {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			return strings.ToLower(value)
+		}
+	}
+	// Repr-Digest (RFC 9530) uses sha-256=:<base64>:, the older Digest header SHA-256=<base64>
+	for _, name := range []string{"Repr-Digest", "Digest"} {
+		for _, entry := range strings.Split(header.Get(name), ",") {
+			algorithm, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || !strings.EqualFold(algorithm, "sha-256") {
+				continue
+			}
+			if sum, err := base64.StdEncoding.DecodeString(strings.Trim(value, ":")); err == nil {
+				return hex.EncodeToString(sum)
+			}
+		}
+	}
+	return ""
+}
+
+// parseContentRange parses "bytes start-end/total"; total is -1 when it is "*"
+func parseContentRange(value string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	span, size, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(span, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	total = -1
+	if size != "*" {
+		if total, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, total, true
+}
+
+// downloadToFile downloads into dest through a ".part" file that is kept when
+// the transfer fails, so a later call resumes where it stopped. The ETag of the
+// content is kept in a ".part.etag" file next to it; a part without one is only
+// resumed when opts.SHA256 can verify the result. dest is only replaced once
+// the content is complete and verified.
+func (c *Client) downloadToFile(ctx context.Context, path string, query url.Values, dest string, opts *DownloadOptions) (*DownloadResult, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	partPath := dest + ".part"
+	etagPath := partPath + ".etag"
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	closed := false
+	defer func() {
+		if !closed {
+			file.Close()
+		}
+	}()
+
+	// Hash what an earlier attempt already received
+	h := sha256.New()
+	offset, err := io.Copy(h, file)
+	if err != nil {
+		return nil, err
+	}
+	restart := func() error {
+		h.Reset()
+		offset = 0
+		if err := os.Remove(etagPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+		_, err := file.Seek(0, io.SeekStart)
+		return err
+	}
+
+	etag := ""
+	if offset > 0 {
+		if data, err := os.ReadFile(etagPath); err == nil {
+			etag = strings.TrimSpace(string(data))
+		}
+	}
+	if offset == 0 || opts.MaxResumes < 0 || etag == "" && strings.TrimSpace(opts.SHA256) == "" {
+		// Nothing can tell whether the part still matches the content
+		etag = ""
+		if err := restart(); err != nil {
+			return nil, err
+		}
+	}
+
+	newState := func() *downloadState {
+		state := newDownloadState(offset, h)
+		state.etag = etag
+		state.saveETag = func(etag string) error {
+			return os.WriteFile(etagPath, []byte(etag+"\n"), 0o644)
+		}
+		return state
+	}
+	result, err := c.download(ctx, path, query, file, newState(), opts)
+	if errors.Is(err, errContentChanged) {
+		etag = ""
+		if err := restart(); err != nil {
+			return nil, err
+		}
+		result, err = c.download(ctx, path, query, file, newState(), opts)
+	}
+	if errors.Is(err, ErrChecksumMismatch) {
+		// The partial file cannot be trusted for a later resume
+		file.Close()
+		closed = true
+		os.Remove(partPath)
+		os.Remove(etagPath)
+		return nil, err
+	}
+	if err != nil {
+		// Keep the part only if a later call has something to resume
+		if info, statErr := file.Stat(); statErr == nil && info.Size() == 0 {
+			file.Close()
+			closed = true
+			os.Remove(partPath)
+			os.Remove(etagPath)
+		}
+		return nil, err
+	}
+
+	if err := file.Sync(); err != nil {
+		return nil, err
+	}
+	closed = true
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(partPath, dest); err != nil {
+		return nil, err
+	}
+	os.Remove(etagPath)
+	return result, nil
+}
+
+// DownloadPluginToFile downloads a plugin archive to dest, resuming a previous
+// partial download of the same file. version may be empty for the latest.
+func (c *PluginMarketplaceClient) DownloadPluginToFile(pluginID, version, dest string, opts *DownloadOptions) (*DownloadResult, error) {
+	return c.DownloadPluginToFileWithContext(context.Background(), pluginID, version, dest, opts)
+}
+
+// DownloadPluginToFileWithContext downloads a plugin archive to dest, resuming a
+// previous partial download of the same file. version may be empty for the latest.
+func (c *PluginMarketplaceClient) DownloadPluginToFileWithContext(ctx context.Context, pluginID, version, dest string, opts *DownloadOptions) (*DownloadResult, error) {
+	path, err := expandPath("/{plugin_id}/download", map[string]interface{}{"plugin_id": pluginID})
+	if err != nil {
+		return nil, err
+	}
+	params := newQuery().Add("version", optionalString(version)).Values()
+	return c.client.downloadToFile(ctx, path, params, dest, opts)
+}
+
+// DownloadBundleToFile downloads a rule bundle to dest, resuming a previous
+// partial download of the same file
+func (c *RegistryClient) DownloadBundleToFile(bundleID, dest string, opts *DownloadOptions) (*DownloadResult, error) {
+	return c.DownloadBundleToFileWithContext(context.Background(), bundleID, dest, opts)
+}
+
+// DownloadBundleToFileWithContext downloads a rule bundle to dest, resuming a
+// previous partial download of the same file
+func (c *RegistryClient) DownloadBundleToFileWithContext(ctx context.Context, bundleID, dest string, opts *DownloadOptions) (*DownloadResult, error) {
+	path, err := expandPath("/bundles/{bundle_id}/download", map[string]interface{}{"bundle_id": bundleID})
+	if err != nil {
+		return nil, err
+	}
+	return c.client.downloadToFile(ctx, path, nil, dest, opts)
+}
+
+// FetchPlugin downloads a plugin archive into w and returns the version that
+// was downloaded. An empty version fetches the latest release, and a
+// constraint such as "^1.2" the highest matching one. It lets the client act
+// as the scanner package's PluginFetcher.
+func (c *PluginMarketplaceClient) FetchPlugin(ctx context.Context, pluginID, version string, w io.Writer) (string, error) {
+	if constraint, err := ParseConstraint(version); err == nil && version != "" {
+		if _, ok := constraint.exact(); !ok {
+			resolved, err := c.ResolveVersionWithContext(ctx, pluginID, version, nil)
+			if err != nil {
+				return "", err
+			}
+			version = resolved
+		}
+	}
+	if version == "" {
+		plugin, err := c.Get{plugin_id}WithContext(ctx, pluginID)
+		if err != nil {
+			return "", err
+		}
+		var info struct {
+			Version       string `json:"version"`
+			LatestVersion string `json:"latest_version"`
+		}
+		if err := decodeResult(plugin, &info); err != nil {
+			return "", err
+		}
+		version = info.LatestVersion
+		if version == "" {
+			version = info.Version
+		}
+		if version == "" {
+			return "", fmt.Errorf("tavo: plugin %s does not report a version", pluginID)
+		}
+	}
+	if _, err := c.Get{plugin_id}downloadWithContext(ctx, pluginID, &version, w, nil); err != nil {
+		return "", err
+	}
+	return version, nil
+}
@@ -0,0 +1,201 @@
+package tavo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// contentServer serves one version of a file, honouring Range and If-Range
+type contentServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	content []byte
+	etag    string
+	abortAt int
+	ranges  []string
+}
+
+func newContentServer(t *testing.T, content []byte, etag string) *contentServer {
+	t.Helper()
+	server := &contentServer{content: content, etag: etag}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serve))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *contentServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, etag, abortAt := s.content, s.etag, s.abortAt
+	s.abortAt = 0
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	s.mu.Unlock()
+
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	start := 0
+	rangeHeader := r.Header.Get("Range")
+	ifRange := r.Header.Get("If-Range")
+	if rangeHeader != "" && (ifRange == "" || ifRange == etag) {
+		start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+		w.Header().Set("Content-Range", "bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(len(content)-1)+"/"+strconv.Itoa(len(content)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+		w.WriteHeader(http.StatusPartialContent)
+	} else {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	}
+	if abortAt > 0 {
+		w.Write(content[start:abortAt])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	w.Write(content[start:])
+}
+
+// requestedRanges returns the Range header of every request so far
+func (s *contentServer) requestedRanges() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+func TestDownloadToFileResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 5000)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name       string
+		part       []byte
+		etag       string
+		opts       *DownloadOptions
+		wantRanges []string
+	}{
+		{"part with stored ETag", content[:1000], `"v1"`, nil, []string{"bytes=1000-"}},
+		{"part without validator", []byte("stale bytes"), "", nil, []string{""}},
+		{"part without validator but checksum", content[:1000], "", &DownloadOptions{SHA256: checksum}, []string{"bytes=1000-"}},
+		{"part with outdated ETag", []byte("old version"), `"v0"`, nil, []string{"bytes=11-", ""}},
+		{"resuming disabled", content[:1000], `"v1"`, &DownloadOptions{MaxResumes: -1}, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newContentServer(t, content, `"v1"`)
+			client := NewClient("test-key", "", server.URL)
+			dest := filepath.Join(t.TempDir(), "bundle.zip")
+			if err := os.WriteFile(dest+".part", tt.part, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.etag != "" {
+				if err := os.WriteFile(dest+".part.etag", []byte(tt.etag+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := client.Registry().DownloadBundleToFile("b1", dest, tt.opts)
+			if err != nil {
+				t.Fatalf("DownloadBundleToFile: %v", err)
+			}
+			if got, _ := os.ReadFile(dest); !bytes.Equal(got, content) {
+				t.Errorf("downloaded %d bytes that do not match the content", len(got))
+			}
+			if result.SHA256 != checksum {
+				t.Errorf("SHA256 = %s, want %s", result.SHA256, checksum)
+			}
+			if ranges := server.requestedRanges(); strings.Join(ranges, ",") != strings.Join(tt.wantRanges, ",") {
+				t.Errorf("Range headers = %q, want %q", ranges, tt.wantRanges)
+			}
+			for _, leftover := range []string{dest + ".part", dest + ".part.etag"} {
+				if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+					t.Errorf("%s was not removed", filepath.Base(leftover))
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadToFileKeepsETagOfInterruptedPart(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefghij"), 5000)
+	server := newContentServer(t, content, `"v1"`)
+	server.abortAt = 20000
+	client := NewClient("test-key", "", server.URL, WithRetryPolicy(NoRetries()))
+	dest := filepath.Join(t.TempDir(), "bundle.zip")
+
+	if _, err := client.Registry().DownloadBundleToFile("b1", dest, &DownloadOptions{MaxResumes: -1}); err == nil {
+		t.Fatal("interrupted download succeeded")
+	}
+	if etag, err := os.ReadFile(dest + ".part.etag"); err != nil || strings.TrimSpace(string(etag)) != `"v1"` {
+		t.Fatalf(".part.etag = %q, %v", etag, err)
+	}
+	if info, err := os.Stat(dest + ".part"); err != nil || info.Size() != 20000 {
+		t.Fatalf(".part = %v, %v", info, err)
+	}
+
+	// A later call resumes the part, as if from a new process
+	if _, err := client.Registry().DownloadBundleToFile("b1", dest, nil); err != nil {
+		t.Fatalf("resumed download: %v", err)
+	}
+	if got, _ := os.ReadFile(dest); !bytes.Equal(got, content) {
+		t.Errorf("resumed download does not match the content")
+	}
+	if ranges := server.requestedRanges(); len(ranges) != 2 || ranges[1] != "bytes=20000-" {
+		t.Errorf("Range headers = %q", ranges)
+	}
+}
+
+func TestDownloadToFileRemovesEmptyPartOnError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "bundle not found"}`))
+	})
+	dest := filepath.Join(t.TempDir(), "bundle.zip")
+
+	if _, err := client.Registry().DownloadBundleToFile("b1", dest, nil); !IsNotFound(err) {
+		t.Fatalf("err = %v, want the 404", err)
+	}
+	for _, leftover := range []string{dest, dest + ".part", dest + ".part.etag"} {
+		if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+			t.Errorf("%s exists after a failed request", filepath.Base(leftover))
+		}
+	}
+
+	// A part from an earlier attempt is kept for the next one
+	if err := os.WriteFile(dest+".part", []byte("0123"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dest+".part.etag", []byte(`"v1"`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Registry().DownloadBundleToFile("b1", dest, nil); !IsNotFound(err) {
+		t.Fatalf("err = %v, want the 404", err)
+	}
+	if part, err := os.ReadFile(dest + ".part"); err != nil || string(part) != "0123" {
+		t.Errorf(".part = %q, %v; want the earlier part kept", part, err)
+	}
+}
+
+func TestDownloadResumesWithoutRetryPolicy(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefghij"), 5000)
+	server := newContentServer(t, content, `"v1"`)
+	server.abortAt = 20000
+	client := NewClient("test-key", "", server.URL)
+	client.retryPolicy = nil
+
+	dest := filepath.Join(t.TempDir(), "bundle.zip")
+	result, err := client.Registry().DownloadBundleToFile("b1", dest, nil)
+	if err != nil {
+		t.Fatalf("DownloadBundleToFile: %v", err)
+	}
+	if got, _ := os.ReadFile(dest); result.Resumes != 1 || !bytes.Equal(got, content) {
+		t.Errorf("Resumes = %d, %d bytes; want one resume and the whole content", result.Resumes, len(got))
+	}
+}
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // AiAnalysisClient handles ai_analysis API calls
@@ -16,333 +11,133 @@ type AiAnalysisClient struct {
 
 // Postanalyze{scan_id} POST /analyze/{scan_id}
 func (c *AiAnalysisClient) Postanalyze{scan_id}(scan_id string, background_tasks interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/analyze/{scan_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"scan_id": scan_id,
-			"background_tasks": background_tasks,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		return c.Postanalyze{scan_id}WithContext(context.Background(), scan_id, background_tasks)
+}
+// Postanalyze{scan_id}WithContext POST /analyze/{scan_id}
+func (c *AiAnalysisClient) Postanalyze{scan_id}WithContext(ctx context.Context, scan_id string, background_tasks interface{}) (interface{}, error) {
+		path, err := expandPath("/analyze/{scan_id}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		body := map[string]interface{}{
+			"background_tasks": background_tasks,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Postclassify{scan_id} POST /classify/{scan_id}
 func (c *AiAnalysisClient) Postclassify{scan_id}(scan_id string, background_tasks interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/classify/{scan_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"scan_id": scan_id,
-			"background_tasks": background_tasks,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		return c.Postclassify{scan_id}WithContext(context.Background(), scan_id, background_tasks)
+}
+// Postclassify{scan_id}WithContext POST /classify/{scan_id}
+func (c *AiAnalysisClient) Postclassify{scan_id}WithContext(ctx context.Context, scan_id string, background_tasks interface{}) (interface{}, error) {
+		path, err := expandPath("/classify/{scan_id}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		body := map[string]interface{}{
+			"background_tasks": background_tasks,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Postriskscore{scan_id} POST /risk-score/{scan_id}
 func (c *AiAnalysisClient) Postriskscore{scan_id}(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/risk-score/{scan_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postriskscore{scan_id}WithContext(context.Background(), scan_id)
+}
+// Postriskscore{scan_id}WithContext POST /risk-score/{scan_id}
+func (c *AiAnalysisClient) Postriskscore{scan_id}WithContext(ctx context.Context, scan_id string) (interface{}, error) {
+		path, err := expandPath("/risk-score/{scan_id}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Postcompliance{scan_id} POST /compliance/{scan_id}
 func (c *AiAnalysisClient) Postcompliance{scan_id}(scan_id float64, framework *string) (interface{}, error) {
-		url := fmt.Sprintf("/compliance/{scan_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"scan_id": scan_id,
-			"framework": framework,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		return c.Postcompliance{scan_id}WithContext(context.Background(), scan_id, framework)
+}
+// Postcompliance{scan_id}WithContext POST /compliance/{scan_id}
+func (c *AiAnalysisClient) Postcompliance{scan_id}WithContext(ctx context.Context, scan_id float64, framework *string) (interface{}, error) {
+		path, err := expandPath("/compliance/{scan_id}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		body := map[string]interface{}{
+			"framework": framework,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Postpredictive{scan_id} POST /predictive/{scan_id}
 func (c *AiAnalysisClient) Postpredictive{scan_id}(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/predictive/{scan_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postpredictive{scan_id}WithContext(context.Background(), scan_id)
+}
+// Postpredictive{scan_id}WithContext POST /predictive/{scan_id}
+func (c *AiAnalysisClient) Postpredictive{scan_id}WithContext(ctx context.Context, scan_id string) (interface{}, error) {
+		path, err := expandPath("/predictive/{scan_id}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Getfixsuggestions GET /fix-suggestions
 func (c *AiAnalysisClient) Getfixsuggestions(search *string, status *string, severity *string, analysis_type *string, limit *float64, offset *float64) (interface{}, error) {
-		url := fmt.Sprintf("/fix-suggestions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("search", fmt.Sprintf("%v", search))
-		params.Add("status", fmt.Sprintf("%v", status))
-		params.Add("severity", fmt.Sprintf("%v", severity))
-		params.Add("analysis_type", fmt.Sprintf("%v", analysis_type))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("offset", fmt.Sprintf("%v", offset))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetfixsuggestionsWithContext(context.Background(), search, status, severity, analysis_type, limit, offset)
+}
+// GetfixsuggestionsWithContext GET /fix-suggestions
+func (c *AiAnalysisClient) GetfixsuggestionsWithContext(ctx context.Context, search *string, status *string, severity *string, analysis_type *string, limit *float64, offset *float64) (interface{}, error) {
+		path := "/fix-suggestions"
+		params := newQuery().
+			Add("search", search).
+			Add("status", status).
+			Add("severity", severity).
+			Add("analysis_type", analysis_type).
+			Add("limit", limit).
+			Add("offset", offset).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getpredictive GET /predictive
 func (c *AiAnalysisClient) Getpredictive(time_horizon *string, severity *string, prediction_type *string, analysis_type *string) (interface{}, error) {
-		url := fmt.Sprintf("/predictive", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("time_horizon", fmt.Sprintf("%v", time_horizon))
-		params.Add("severity", fmt.Sprintf("%v", severity))
-		params.Add("prediction_type", fmt.Sprintf("%v", prediction_type))
-		params.Add("analysis_type", fmt.Sprintf("%v", analysis_type))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetpredictiveWithContext(context.Background(), time_horizon, severity, prediction_type, analysis_type)
+}
+// GetpredictiveWithContext GET /predictive
+func (c *AiAnalysisClient) GetpredictiveWithContext(ctx context.Context, time_horizon *string, severity *string, prediction_type *string, analysis_type *string) (interface{}, error) {
+		path := "/predictive"
+		params := newQuery().
+			Add("time_horizon", time_horizon).
+			Add("severity", severity).
+			Add("prediction_type", prediction_type).
+			Add("analysis_type", analysis_type).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getcompliance GET /compliance
 func (c *AiAnalysisClient) Getcompliance(framework *string, status *string, risk_level *string, category *string) (interface{}, error) {
-		url := fmt.Sprintf("/compliance", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("framework", fmt.Sprintf("%v", framework))
-		params.Add("status", fmt.Sprintf("%v", status))
-		params.Add("risk_level", fmt.Sprintf("%v", risk_level))
-		params.Add("category", fmt.Sprintf("%v", category))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetcomplianceWithContext(context.Background(), framework, status, risk_level, category)
+}
+// GetcomplianceWithContext GET /compliance
+func (c *AiAnalysisClient) GetcomplianceWithContext(ctx context.Context, framework *string, status *string, risk_level *string, category *string) (interface{}, error) {
+		path := "/compliance"
+		params := newQuery().
+			Add("framework", framework).
+			Add("status", status).
+			Add("risk_level", risk_level).
+			Add("category", category).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // AiAnalysisCoreClient handles ai_analysis_core API calls
@@ -16,83 +11,35 @@ type AiAnalysisCoreClient struct {
 
 // Getanalyses GET /analyses
 func (c *AiAnalysisCoreClient) Getanalyses(skip *float64, limit *float64, scan_id *string, analysis_type *string, status *string, start_date *string, end_date *string) (interface{}, error) {
-		url := fmt.Sprintf("/analyses", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("skip", fmt.Sprintf("%v", skip))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("scan_id", fmt.Sprintf("%v", scan_id))
-		params.Add("analysis_type", fmt.Sprintf("%v", analysis_type))
-		params.Add("status", fmt.Sprintf("%v", status))
-		params.Add("start_date", fmt.Sprintf("%v", start_date))
-		params.Add("end_date", fmt.Sprintf("%v", end_date))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetanalysesWithContext(context.Background(), skip, limit, scan_id, analysis_type, status, start_date, end_date)
+}
+// GetanalysesWithContext GET /analyses
+func (c *AiAnalysisCoreClient) GetanalysesWithContext(ctx context.Context, skip *float64, limit *float64, scan_id *string, analysis_type *string, status *string, start_date *string, end_date *string) (interface{}, error) {
+		path := "/analyses"
+		params := newQuery().
+			Add("skip", skip).
+			Add("limit", limit).
+			Add("scan_id", scan_id).
+			Add("analysis_type", analysis_type).
+			Add("status", status).
+			Add("start_date", start_date).
+			Add("end_date", end_date).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getanalyses{analysis_id} GET /analyses/{analysis_id}
 func (c *AiAnalysisCoreClient) Getanalyses{analysis_id}(analysis_id string) (interface{}, error) {
-		url := fmt.Sprintf("/analyses/{analysis_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("analysis_id", fmt.Sprintf("%v", analysis_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getanalyses{analysis_id}WithContext(context.Background(), analysis_id)
+}
+// Getanalyses{analysis_id}WithContext GET /analyses/{analysis_id}
+func (c *AiAnalysisCoreClient) Getanalyses{analysis_id}WithContext(ctx context.Context, analysis_id string) (interface{}, error) {
+		path, err := expandPath("/analyses/{analysis_id}", map[string]interface{}{"analysis_id": analysis_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // AiBulkOperationsClient handles ai_bulk_operations API calls
@@ -16,118 +11,38 @@ type AiBulkOperationsClient struct {
 
 // Deletebulkdelete DELETE /bulk/delete
 func (c *AiBulkOperationsClient) Deletebulkdelete(analysis_ids *[]string) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/delete", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(analysis_ids)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.DeletebulkdeleteWithContext(context.Background(), analysis_ids)
+}
+// DeletebulkdeleteWithContext DELETE /bulk/delete
+func (c *AiBulkOperationsClient) DeletebulkdeleteWithContext(ctx context.Context, analysis_ids *[]string) (interface{}, error) {
+		path := "/bulk/delete"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "DELETE", path, nil, analysis_ids, &result)
+		return result, err
 }
 // Putbulkupdatestatus PUT /bulk/update-status
 func (c *AiBulkOperationsClient) Putbulkupdatestatus(analysis_updates *[]interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/update-status", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(analysis_updates)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PutbulkupdatestatusWithContext(context.Background(), analysis_updates)
+}
+// PutbulkupdatestatusWithContext PUT /bulk/update-status
+func (c *AiBulkOperationsClient) PutbulkupdatestatusWithContext(ctx context.Context, analysis_updates *[]interface{}) (interface{}, error) {
+		path := "/bulk/update-status"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "PUT", path, nil, analysis_updates, &result)
+		return result, err
 }
 // Getbulkexport GET /bulk/export
 func (c *AiBulkOperationsClient) Getbulkexport(analysis_ids *[]string, export_format *string) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/export", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("analysis_ids", fmt.Sprintf("%v", analysis_ids))
-		params.Add("export_format", fmt.Sprintf("%v", export_format))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetbulkexportWithContext(context.Background(), analysis_ids, export_format)
+}
+// GetbulkexportWithContext GET /bulk/export
+func (c *AiBulkOperationsClient) GetbulkexportWithContext(ctx context.Context, analysis_ids *[]string, export_format *string) (interface{}, error) {
+		path := "/bulk/export"
+		params := newQuery().
+			Add("analysis_ids", analysis_ids).
+			Add("export_format", export_format).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // CodeSubmissionClient handles code_submission API calls
@@ -16,216 +11,84 @@ type CodeSubmissionClient struct {
 
 // Postsubmitcode POST /submit/code
 func (c *CodeSubmissionClient) Postsubmitcode(files *[]interface{}, scan_config *interface{}, repository_name *string, branch *string, commit_sha *string) (interface{}, error) {
-		url := fmt.Sprintf("/submit/code", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
+		return c.PostsubmitcodeWithContext(context.Background(), files, scan_config, repository_name, branch, commit_sha)
+}
+// PostsubmitcodeWithContext POST /submit/code
+func (c *CodeSubmissionClient) PostsubmitcodeWithContext(ctx context.Context, files *[]interface{}, scan_config *interface{}, repository_name *string, branch *string, commit_sha *string) (interface{}, error) {
+		path := "/submit/code"
+		body := map[string]interface{}{
 			"files": files,
 			"scan_config": scan_config,
 			"repository_name": repository_name,
 			"branch": branch,
 			"commit_sha": commit_sha,
 		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Postsubmitrepository POST /submit/repository
 func (c *CodeSubmissionClient) Postsubmitrepository(repository_url *string, snapshot_data *interface{}, scan_config *interface{}, branch *string, commit_sha *string) (interface{}, error) {
-		url := fmt.Sprintf("/submit/repository", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
+		return c.PostsubmitrepositoryWithContext(context.Background(), repository_url, snapshot_data, scan_config, branch, commit_sha)
+}
+// PostsubmitrepositoryWithContext POST /submit/repository
+func (c *CodeSubmissionClient) PostsubmitrepositoryWithContext(ctx context.Context, repository_url *string, snapshot_data *interface{}, scan_config *interface{}, branch *string, commit_sha *string) (interface{}, error) {
+		path := "/submit/repository"
+		body := map[string]interface{}{
 			"repository_url": repository_url,
 			"snapshot_data": snapshot_data,
 			"scan_config": scan_config,
 			"branch": branch,
 			"commit_sha": commit_sha,
 		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Postsubmitanalysis POST /submit/analysis
-func (c *CodeSubmissionClient) Postsubmitanalysis(code_content *string, language *string, analysis_type *string, rules *[]string, plugins *[]string, context *interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/submit/analysis", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
+func (c *CodeSubmissionClient) Postsubmitanalysis(code_content *string, language *string, analysis_type *string, rules *[]string, plugins *[]string, code_context *interface{}) (interface{}, error) {
+		return c.PostsubmitanalysisWithContext(context.Background(), code_content, language, analysis_type, rules, plugins, code_context)
+}
+// PostsubmitanalysisWithContext POST /submit/analysis
+func (c *CodeSubmissionClient) PostsubmitanalysisWithContext(ctx context.Context, code_content *string, language *string, analysis_type *string, rules *[]string, plugins *[]string, code_context *interface{}) (interface{}, error) {
+		path := "/submit/analysis"
+		body := map[string]interface{}{
 			"code_content": code_content,
 			"language": language,
 			"analysis_type": analysis_type,
 			"rules": rules,
 			"plugins": plugins,
-			"context": context,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+			"context": code_context,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Getscans{scan_id}status GET /scans/{scan_id}/status
 func (c *CodeSubmissionClient) Getscans{scan_id}status(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/scans/{scan_id}/status", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("scan_id", fmt.Sprintf("%v", scan_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getscans{scan_id}statusWithContext(context.Background(), scan_id)
+}
+// Getscans{scan_id}statusWithContext GET /scans/{scan_id}/status
+func (c *CodeSubmissionClient) Getscans{scan_id}statusWithContext(ctx context.Context, scan_id string) (interface{}, error) {
+		path, err := expandPath("/scans/{scan_id}/status", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getscans{scan_id}resultssummary GET /scans/{scan_id}/results/summary
 func (c *CodeSubmissionClient) Getscans{scan_id}resultssummary(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/scans/{scan_id}/results/summary", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("scan_id", fmt.Sprintf("%v", scan_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getscans{scan_id}resultssummaryWithContext(context.Background(), scan_id)
+}
+// Getscans{scan_id}resultssummaryWithContext GET /scans/{scan_id}/results/summary
+func (c *CodeSubmissionClient) Getscans{scan_id}resultssummaryWithContext(ctx context.Context, scan_id string) (interface{}, error) {
+		path, err := expandPath("/scans/{scan_id}/results/summary", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // DeviceAuthClient handles device_auth API calls
@@ -16,303 +11,99 @@ type DeviceAuthClient struct {
 
 // Postcode POST /code
 func (c *DeviceAuthClient) Postcode(client_id *string, client_name *string) (interface{}, error) {
-		url := fmt.Sprintf("/code", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
+		return c.PostcodeWithContext(context.Background(), client_id, client_name)
+}
+// PostcodeWithContext POST /code
+func (c *DeviceAuthClient) PostcodeWithContext(ctx context.Context, client_id *string, client_name *string) (interface{}, error) {
+		path := "/code"
+		body := map[string]interface{}{
 			"client_id": client_id,
 			"client_name": client_name,
 		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Posttoken POST /token
 func (c *DeviceAuthClient) Posttoken(device_code string) (interface{}, error) {
-		url := fmt.Sprintf("/token", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(device_code)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PosttokenWithContext(context.Background(), device_code)
+}
+// PosttokenWithContext POST /token
+func (c *DeviceAuthClient) PosttokenWithContext(ctx context.Context, device_code string) (interface{}, error) {
+		path := "/token"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, device_code, &result)
+		return result, err
 }
 // Getinfo GET /info
 func (c *DeviceAuthClient) Getinfo(user_code string) (interface{}, error) {
-		url := fmt.Sprintf("/info", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("user_code", fmt.Sprintf("%v", user_code))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetinfoWithContext(context.Background(), user_code)
+}
+// GetinfoWithContext GET /info
+func (c *DeviceAuthClient) GetinfoWithContext(ctx context.Context, user_code string) (interface{}, error) {
+		path := "/info"
+		params := newQuery().
+			Add("user_code", user_code).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Postapprove POST /approve
 func (c *DeviceAuthClient) Postapprove() (interface{}, error) {
-		url := fmt.Sprintf("/approve", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostapproveWithContext(context.Background())
+}
+// PostapproveWithContext POST /approve
+func (c *DeviceAuthClient) PostapproveWithContext(ctx context.Context) (interface{}, error) {
+		path := "/approve"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Postcodecli POST /code/cli
 func (c *DeviceAuthClient) Postcodecli(client_name *string) (interface{}, error) {
-		url := fmt.Sprintf("/code/cli", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(client_name)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostcodecliWithContext(context.Background(), client_name)
+}
+// PostcodecliWithContext POST /code/cli
+func (c *DeviceAuthClient) PostcodecliWithContext(ctx context.Context, client_name *string) (interface{}, error) {
+		path := "/code/cli"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, client_name, &result)
+		return result, err
 }
 // Getcode{device_code}status GET /code/{device_code}/status
 func (c *DeviceAuthClient) Getcode{device_code}status(device_code string) (interface{}, error) {
-		url := fmt.Sprintf("/code/{device_code}/status", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("device_code", fmt.Sprintf("%v", device_code))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getcode{device_code}statusWithContext(context.Background(), device_code)
+}
+// Getcode{device_code}statusWithContext GET /code/{device_code}/status
+func (c *DeviceAuthClient) Getcode{device_code}statusWithContext(ctx context.Context, device_code string) (interface{}, error) {
+		path, err := expandPath("/code/{device_code}/status", map[string]interface{}{"device_code": device_code})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getusagewarnings GET /usage/warnings
 func (c *DeviceAuthClient) Getusagewarnings() (interface{}, error) {
-		url := fmt.Sprintf("/usage/warnings", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetusagewarningsWithContext(context.Background())
+}
+// GetusagewarningsWithContext GET /usage/warnings
+func (c *DeviceAuthClient) GetusagewarningsWithContext(ctx context.Context) (interface{}, error) {
+		path := "/usage/warnings"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getlimits GET /limits
 func (c *DeviceAuthClient) Getlimits() (interface{}, error) {
-		url := fmt.Sprintf("/limits", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetlimitsWithContext(context.Background())
+}
+// GetlimitsWithContext GET /limits
+func (c *DeviceAuthClient) GetlimitsWithContext(ctx context.Context) (interface{}, error) {
+		path := "/limits"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
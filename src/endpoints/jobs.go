@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // JobsClient handles jobs API calls
@@ -16,79 +11,35 @@ type JobsClient struct {
 
 // Getstatus{job_id} GET /status/{job_id}
 func (c *JobsClient) Getstatus{job_id}(job_id string) (interface{}, error) {
-		url := fmt.Sprintf("/status/{job_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("job_id", fmt.Sprintf("%v", job_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getstatus{job_id}WithContext(context.Background(), job_id)
+}
+// Getstatus{job_id}WithContext GET /status/{job_id}
+func (c *JobsClient) Getstatus{job_id}WithContext(ctx context.Context, job_id string) (interface{}, error) {
+		path, err := expandPath("/status/{job_id}", map[string]interface{}{"job_id": job_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getdashboard GET /dashboard
 func (c *JobsClient) Getdashboard(limit *float64, authorization *string, x_api_key *string) (interface{}, error) {
-		url := fmt.Sprintf("/dashboard", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("authorization", fmt.Sprintf("%v", authorization))
-		params.Add("x_api_key", fmt.Sprintf("%v", x_api_key))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		return c.GetdashboardWithContext(context.Background(), limit, authorization, x_api_key)
+}
+// GetdashboardWithContext GET /dashboard
+func (c *JobsClient) GetdashboardWithContext(ctx context.Context, limit *float64, authorization *string, x_api_key *string) (interface{}, error) {
+		path := "/dashboard"
+		params := newQuery().
+			Add("limit", limit).
+			Values()
+		if authorization != nil {
+			ctx = withRequestHeader(ctx, "Authorization", *authorization)
+		}
+		if x_api_key != nil {
+			ctx = withRequestHeader(ctx, "X-API-Key", *x_api_key)
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
@@ -0,0 +1,30 @@
+package tavo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetdashboardSendsCredentialParamsAsHeaders(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`{}`))
+	})
+
+	limit := 5.0
+	authorization := "Bearer other-token"
+	apiKey := "other-key"
+	if _, err := client.Jobs().Getdashboard(&limit, &authorization, &apiKey); err != nil {
+		t.Fatalf("Getdashboard: %v", err)
+	}
+	if query := got.URL.RawQuery; query != "limit=5" {
+		t.Errorf("query = %q, want only the limit", query)
+	}
+	if value := got.Header.Get("Authorization"); value != authorization {
+		t.Errorf("Authorization = %q, want %q", value, authorization)
+	}
+	if value := got.Header.Get("X-API-Key"); value != apiKey {
+		t.Errorf("X-API-Key = %q, want %q", value, apiKey)
+	}
+}
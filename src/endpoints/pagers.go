@@ -0,0 +1,93 @@
+package tavo
+
+import (
+	"context"
+)
+
+// pageNumber returns the page number of a request as an API parameter
+func pageNumber(page PageRequest) *float64 {
+	value := float64(page.Page)
+	return &value
+}
+
+// pageOffset returns the item offset of a request as an API parameter
+func pageOffset(page PageRequest) *float64 {
+	value := float64(page.Offset)
+	return &value
+}
+
+// pageSize returns the page size of a request as an API parameter
+func pageSize(page PageRequest) *float64 {
+	value := float64(page.Size)
+	return &value
+}
+
+// GetRootPager pages through GET / using skip/limit
+func (c *ScanManagementClient) GetRootPager(status_filter *string, organization_id *string, opts ...PagerOption) *Pager[Scan] {
+	return NewPager[Scan](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetRootWithContext(ctx, pageOffset(page), pageSize(page), status_filter, organization_id)
+	}, opts...)
+}
+
+// GetrulesPager pages through GET /rules using skip/limit
+func (c *ScanRulesClient) GetrulesPager(tool_filter *string, category_filter *string, severity_filter *string, language_filter *string, is_active *bool, organization_id *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetrulesWithContext(ctx, pageOffset(page), pageSize(page), tool_filter, category_filter, severity_filter, language_filter, is_active, organization_id)
+	}, opts...)
+}
+
+// GetanalysesPager pages through GET /analyses using skip/limit
+func (c *AiAnalysisCoreClient) GetanalysesPager(scan_id *string, analysis_type *string, status *string, start_date *string, end_date *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetanalysesWithContext(ctx, pageOffset(page), pageSize(page), scan_id, analysis_type, status, start_date, end_date)
+	}, opts...)
+}
+
+// GetresultsPager pages through GET /results using skip/limit
+func (c *AiResultsExportClient) GetresultsPager(scan_id *string, analysis_type *string, severity *string, start_date *string, end_date *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetresultsWithContext(ctx, pageOffset(page), pageSize(page), scan_id, analysis_type, severity, start_date, end_date)
+	}, opts...)
+}
+
+// GetfixsuggestionsPager pages through GET /fix-suggestions using limit/offset
+func (c *AiAnalysisClient) GetfixsuggestionsPager(search *string, status *string, severity *string, analysis_type *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetfixsuggestionsWithContext(ctx, search, status, severity, analysis_type, pageSize(page), pageOffset(page))
+	}, opts...)
+}
+
+// GetRootPager pages through GET / using page/per_page
+func (c *RepositoriesClient) GetRootPager(connection_id *string, language *string, scan_enabled *bool, search *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetRootWithContext(ctx, connection_id, language, scan_enabled, search, pageNumber(page), pageSize(page))
+	}, opts...)
+}
+
+// GetmarketplacePager pages through GET /marketplace using page/per_page
+func (c *PluginMarketplaceClient) GetmarketplacePager(plugin_type *string, category *string, pricing_tier *string, search *string, is_official *bool, is_vetted *bool, min_rating *float64, sort_by *string, sort_order *string, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetmarketplaceWithContext(ctx, plugin_type, category, pricing_tier, search, is_official, is_vetted, min_rating, pageNumber(page), pageSize(page), sort_by, sort_order)
+	}, opts...)
+}
+
+// GetbundlesPager pages through GET /bundles using page/per_page
+func (c *RulesClient) GetbundlesPager(category *string, official_only *bool, opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetbundlesWithContext(ctx, category, official_only, pageNumber(page), pageSize(page))
+	}, opts...)
+}
+
+// GetmyexecutionsPager pages through GET /my-executions using page/per_page
+func (c *RegistryClient) GetmyexecutionsPager(opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetmyexecutionsWithContext(ctx, pageNumber(page), pageSize(page))
+	}, opts...)
+}
+
+// GetmybundlesPager iterates GET /my-bundles, which returns every bundle in one response
+func (c *RegistryClient) GetmybundlesPager(opts ...PagerOption) *Pager[map[string]interface{}] {
+	return NewPager[map[string]interface{}](func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return c.GetmybundlesWithContext(ctx)
+	}, append(opts, withoutPaging())...)
+}
@@ -0,0 +1,158 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// listServer serves total numbered items for whichever pagination parameters it receives
+type listServer struct {
+	mu      sync.Mutex
+	total   int
+	queries []url.Values
+}
+
+func (s *listServer) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	s.queries = append(s.queries, query)
+	s.mu.Unlock()
+
+	number := func(name string) int {
+		n, _ := strconv.Atoi(query.Get(name))
+		return n
+	}
+	var start, size int
+	switch {
+	case query.Has("skip"):
+		start, size = number("skip"), number("limit")
+	case query.Has("offset"):
+		start, size = number("offset"), number("limit")
+	default:
+		start, size = (number("page")-1)*number("per_page"), number("per_page")
+	}
+
+	var items []string
+	for i := start; i < s.total && i < start+size; i++ {
+		items = append(items, fmt.Sprintf(`{"id": "item-%d", "status": "completed"}`, i))
+	}
+	list := "[" + strings.Join(items, ",") + "]"
+	if strings.HasSuffix(r.URL.Path, "/fix-suggestions") {
+		fmt.Fprintf(w, `{"items": %s, "total": %d}`, list, s.total)
+		return
+	}
+	w.Write([]byte(list))
+}
+
+// pageQueries returns the pagination parameters of every request
+func (s *listServer) pageQueries(names ...string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var got []string
+	for _, query := range s.queries {
+		var parts []string
+		for _, name := range names {
+			parts = append(parts, name+"="+query.Get(name))
+		}
+		got = append(got, strings.Join(parts, "&"))
+	}
+	return got
+}
+
+func TestPagersUseEachPaginationScheme(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []string
+		ids    func(client *Client) ([]string, error)
+		want   []string
+	}{
+		{
+			name:   "skip/limit",
+			params: []string{"skip", "limit"},
+			ids: func(client *Client) ([]string, error) {
+				scans, err := client.ScanManagement().GetRootPager(nil, nil, WithPageSize(2)).Collect(context.Background())
+				var ids []string
+				for _, scan := range scans {
+					ids = append(ids, scan.ID)
+				}
+				return ids, err
+			},
+			want: []string{"skip=0&limit=2", "skip=2&limit=2", "skip=4&limit=2"},
+		},
+		{
+			name:   "page/per_page",
+			params: []string{"page", "per_page"},
+			ids: func(client *Client) ([]string, error) {
+				repos, err := client.Repositories().GetRootPager(nil, nil, nil, nil, WithPageSize(2)).Collect(context.Background())
+				var ids []string
+				for _, repo := range repos {
+					ids = append(ids, repo["id"].(string))
+				}
+				return ids, err
+			},
+			want: []string{"page=1&per_page=2", "page=2&per_page=2", "page=3&per_page=2"},
+		},
+		{
+			name:   "limit/offset",
+			params: []string{"limit", "offset"},
+			ids: func(client *Client) ([]string, error) {
+				suggestions, err := client.AiAnalysis().GetfixsuggestionsPager(nil, nil, nil, nil, WithPageSize(2)).Collect(context.Background())
+				var ids []string
+				for _, suggestion := range suggestions {
+					ids = append(ids, suggestion["id"].(string))
+				}
+				return ids, err
+			},
+			want: []string{"limit=2&offset=0", "limit=2&offset=2", "limit=2&offset=4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &listServer{total: 5}
+			client := newTestClient(t, server.serve)
+
+			ids, err := tt.ids(client)
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+			if want := []string{"item-0", "item-1", "item-2", "item-3", "item-4"}; strings.Join(ids, ",") != strings.Join(want, ",") {
+				t.Errorf("ids = %q, want %q", ids, want)
+			}
+			if got := server.pageQueries(tt.params...); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("requests = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagerStopsOnAPIError(t *testing.T) {
+	server := &listServer{total: 10}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": "forbidden"}`))
+			return
+		}
+		server.serve(w, r)
+	})
+
+	var ids []string
+	for repo, err := range client.Repositories().GetRootPager(nil, nil, nil, nil, WithPageSize(3)).All(context.Background()) {
+		if err != nil {
+			if !IsForbidden(err) {
+				t.Errorf("err = %v, want a 403 APIError", err)
+			}
+			break
+		}
+		ids = append(ids, repo["id"].(string))
+	}
+	if len(ids) != 3 {
+		t.Errorf("ids = %q, want the first page", ids)
+	}
+}
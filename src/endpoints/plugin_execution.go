@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // PluginExecutionClient handles plugin_execution API calls
@@ -16,117 +11,41 @@ type PluginExecutionClient struct {
 
 // Postexecute POST /execute
 func (c *PluginExecutionClient) Postexecute(background_tasks interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/execute", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(background_tasks)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostexecuteWithContext(context.Background(), background_tasks)
+}
+// PostexecuteWithContext POST /execute
+func (c *PluginExecutionClient) PostexecuteWithContext(ctx context.Context, background_tasks interface{}) (interface{}, error) {
+		path := "/execute"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, background_tasks, &result)
+		return result, err
 }
 // Getexecutions{execution_id} GET /executions/{execution_id}
 func (c *PluginExecutionClient) Getexecutions{execution_id}(execution_id string) (interface{}, error) {
-		url := fmt.Sprintf("/executions/{execution_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("execution_id", fmt.Sprintf("%v", execution_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getexecutions{execution_id}WithContext(context.Background(), execution_id)
+}
+// Getexecutions{execution_id}WithContext GET /executions/{execution_id}
+func (c *PluginExecutionClient) Getexecutions{execution_id}WithContext(ctx context.Context, execution_id string) (interface{}, error) {
+		path, err := expandPath("/executions/{execution_id}", map[string]interface{}{"execution_id": execution_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getexecutions GET /executions
 func (c *PluginExecutionClient) Getexecutions(plugin_id *string, limit *float64) (interface{}, error) {
-		url := fmt.Sprintf("/executions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetexecutionsWithContext(context.Background(), plugin_id, limit)
+}
+// GetexecutionsWithContext GET /executions
+func (c *PluginExecutionClient) GetexecutionsWithContext(ctx context.Context, plugin_id *string, limit *float64) (interface{}, error) {
+		path := "/executions"
+		params := newQuery().
+			Add("plugin_id", plugin_id).
+			Add("limit", limit).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
@@ -1,12 +1,8 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"io"
-	"net/http"
-	"net/url"
 )
 
 // PluginMarketplaceClient handles plugin_marketplace API calls
@@ -16,661 +12,243 @@ type PluginMarketplaceClient struct {
 
 // Getmarketplace GET /marketplace
 func (c *PluginMarketplaceClient) Getmarketplace(plugin_type *string, category *string, pricing_tier *string, search *string, is_official *bool, is_vetted *bool, min_rating *float64, page *float64, per_page *float64, sort_by *string, sort_order *string) (interface{}, error) {
-		url := fmt.Sprintf("/marketplace", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_type", fmt.Sprintf("%v", plugin_type))
-		params.Add("category", fmt.Sprintf("%v", category))
-		params.Add("pricing_tier", fmt.Sprintf("%v", pricing_tier))
-		params.Add("search", fmt.Sprintf("%v", search))
-		params.Add("is_official", fmt.Sprintf("%v", is_official))
-		params.Add("is_vetted", fmt.Sprintf("%v", is_vetted))
-		params.Add("min_rating", fmt.Sprintf("%v", min_rating))
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("per_page", fmt.Sprintf("%v", per_page))
-		params.Add("sort_by", fmt.Sprintf("%v", sort_by))
-		params.Add("sort_order", fmt.Sprintf("%v", sort_order))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		return c.GetmarketplaceWithContext(context.Background(), plugin_type, category, pricing_tier, search, is_official, is_vetted, min_rating, page, per_page, sort_by, sort_order)
+}
+// GetmarketplaceWithContext GET /marketplace
+func (c *PluginMarketplaceClient) GetmarketplaceWithContext(ctx context.Context, plugin_type *string, category *string, pricing_tier *string, search *string, is_official *bool, is_vetted *bool, min_rating *float64, page *float64, per_page *float64, sort_by *string, sort_order *string) (interface{}, error) {
+		path := "/marketplace"
+		params := newQuery().
+			Add("plugin_type", plugin_type).
+			Add("category", category).
+			Add("pricing_tier", pricing_tier).
+			Add("search", search).
+			Add("is_official", is_official).
+			Add("is_vetted", is_vetted).
+			Add("min_rating", min_rating).
+			Add("page", page).
+			Add("per_page", per_page).
+			Add("sort_by", sort_by).
+			Add("sort_order", sort_order).
+			Values()
+		var result interface{}
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{plugin_id} GET /{plugin_id}
 func (c *PluginMarketplaceClient) Get{plugin_id}(plugin_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{plugin_id}WithContext(context.Background(), plugin_id)
+}
+// Get{plugin_id}WithContext GET /{plugin_id}
+func (c *PluginMarketplaceClient) Get{plugin_id}WithContext(ctx context.Context, plugin_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Post{plugin_id}install POST /{plugin_id}/install
 func (c *PluginMarketplaceClient) Post{plugin_id}install(plugin_id string, organization_id *string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/install", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"organization_id": organization_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		return c.Post{plugin_id}installWithContext(context.Background(), plugin_id, organization_id)
+}
+// Post{plugin_id}installWithContext POST /{plugin_id}/install
+func (c *PluginMarketplaceClient) Post{plugin_id}installWithContext(ctx context.Context, plugin_id string, organization_id *string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/install", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		body := map[string]interface{}{
+			"organization_id": organization_id,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Get{plugin_id}download GET /{plugin_id}/download
-func (c *PluginMarketplaceClient) Get{plugin_id}download(plugin_id string, version *string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/download", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		params.Add("version", fmt.Sprintf("%v", version))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
+func (c *PluginMarketplaceClient) Get{plugin_id}download(plugin_id string, version *string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+		return c.Get{plugin_id}downloadWithContext(context.Background(), plugin_id, version, w, opts)
+}
+// Get{plugin_id}downloadWithContext GET /{plugin_id}/download
+func (c *PluginMarketplaceClient) Get{plugin_id}downloadWithContext(ctx context.Context, plugin_id string, version *string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+		path, err := expandPath("/{plugin_id}/download", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		params := newQuery().
+			Add("version", version).
+			Values()
+		return c.client.download(ctx, path, params, w, newDownloadState(0, nil), opts)
 }
 // Getinstalled GET /installed
 func (c *PluginMarketplaceClient) Getinstalled() (interface{}, error) {
-		url := fmt.Sprintf("/installed", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetinstalledWithContext(context.Background())
+}
+// GetinstalledWithContext GET /installed
+func (c *PluginMarketplaceClient) GetinstalledWithContext(ctx context.Context) (interface{}, error) {
+		path := "/installed"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Put{plugin_id} PUT /{plugin_id}
 func (c *PluginMarketplaceClient) Put{plugin_id}(plugin_id string, plugin_data interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"plugin_data": plugin_data,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Put{plugin_id}WithContext(context.Background(), plugin_id, plugin_data)
+}
+// Put{plugin_id}WithContext PUT /{plugin_id}
+func (c *PluginMarketplaceClient) Put{plugin_id}WithContext(ctx context.Context, plugin_id string, plugin_data interface{}) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, plugin_data, &result)
+		return result, err
 }
 // Delete{plugin_id} DELETE /{plugin_id}
 func (c *PluginMarketplaceClient) Delete{plugin_id}(plugin_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(plugin_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Delete{plugin_id}WithContext(context.Background(), plugin_id)
+}
+// Delete{plugin_id}WithContext DELETE /{plugin_id}
+func (c *PluginMarketplaceClient) Delete{plugin_id}WithContext(ctx context.Context, plugin_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Post{plugin_id}publish POST /{plugin_id}/publish
 func (c *PluginMarketplaceClient) Post{plugin_id}publish(plugin_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/publish", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(plugin_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{plugin_id}publishWithContext(context.Background(), plugin_id)
+}
+// Post{plugin_id}publishWithContext POST /{plugin_id}/publish
+func (c *PluginMarketplaceClient) Post{plugin_id}publishWithContext(ctx context.Context, plugin_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/publish", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Post{plugin_id}versions POST /{plugin_id}/versions
 func (c *PluginMarketplaceClient) Post{plugin_id}versions(plugin_id string, version_data interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/versions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"version_data": version_data,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{plugin_id}versionsWithContext(context.Background(), plugin_id, version_data)
+}
+// Post{plugin_id}versionsWithContext POST /{plugin_id}/versions
+func (c *PluginMarketplaceClient) Post{plugin_id}versionsWithContext(ctx context.Context, plugin_id string, version_data interface{}) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/versions", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, version_data, &result)
+		return result, err
 }
 // Get{plugin_id}versions GET /{plugin_id}/versions
 func (c *PluginMarketplaceClient) Get{plugin_id}versions(plugin_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/versions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{plugin_id}versionsWithContext(context.Background(), plugin_id)
+}
+// Get{plugin_id}versionsWithContext GET /{plugin_id}/versions
+func (c *PluginMarketplaceClient) Get{plugin_id}versionsWithContext(ctx context.Context, plugin_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/versions", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Get{plugin_id}reviews GET /{plugin_id}/reviews
 func (c *PluginMarketplaceClient) Get{plugin_id}reviews(plugin_id string, page *float64, limit *float64, min_rating *float64, sort_by *string, sort_order *string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("min_rating", fmt.Sprintf("%v", min_rating))
-		params.Add("sort_by", fmt.Sprintf("%v", sort_by))
-		params.Add("sort_order", fmt.Sprintf("%v", sort_order))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{plugin_id}reviewsWithContext(context.Background(), plugin_id, page, limit, min_rating, sort_by, sort_order)
+}
+// Get{plugin_id}reviewsWithContext GET /{plugin_id}/reviews
+func (c *PluginMarketplaceClient) Get{plugin_id}reviewsWithContext(ctx context.Context, plugin_id string, page *float64, limit *float64, min_rating *float64, sort_by *string, sort_order *string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("page", page).
+			Add("limit", limit).
+			Add("min_rating", min_rating).
+			Add("sort_by", sort_by).
+			Add("sort_order", sort_order).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Post{plugin_id}reviews POST /{plugin_id}/reviews
 func (c *PluginMarketplaceClient) Post{plugin_id}reviews(plugin_id string, review_data interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"review_data": review_data,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{plugin_id}reviewsWithContext(context.Background(), plugin_id, review_data)
+}
+// Post{plugin_id}reviewsWithContext POST /{plugin_id}/reviews
+func (c *PluginMarketplaceClient) Post{plugin_id}reviewsWithContext(ctx context.Context, plugin_id string, review_data interface{}) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews", map[string]interface{}{"plugin_id": plugin_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, review_data, &result)
+		return result, err
 }
 // Get{plugin_id}reviews{review_id} GET /{plugin_id}/reviews/{review_id}
 func (c *PluginMarketplaceClient) Get{plugin_id}reviews{review_id}(plugin_id string, review_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews/{review_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("plugin_id", fmt.Sprintf("%v", plugin_id))
-		params.Add("review_id", fmt.Sprintf("%v", review_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{plugin_id}reviews{review_id}WithContext(context.Background(), plugin_id, review_id)
+}
+// Get{plugin_id}reviews{review_id}WithContext GET /{plugin_id}/reviews/{review_id}
+func (c *PluginMarketplaceClient) Get{plugin_id}reviews{review_id}WithContext(ctx context.Context, plugin_id string, review_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews/{review_id}", map[string]interface{}{"plugin_id": plugin_id, "review_id": review_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Put{plugin_id}reviews{review_id} PUT /{plugin_id}/reviews/{review_id}
 func (c *PluginMarketplaceClient) Put{plugin_id}reviews{review_id}(plugin_id string, review_id string, review_update interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews/{review_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"review_id": review_id,
-			"review_update": review_update,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Put{plugin_id}reviews{review_id}WithContext(context.Background(), plugin_id, review_id, review_update)
+}
+// Put{plugin_id}reviews{review_id}WithContext PUT /{plugin_id}/reviews/{review_id}
+func (c *PluginMarketplaceClient) Put{plugin_id}reviews{review_id}WithContext(ctx context.Context, plugin_id string, review_id string, review_update interface{}) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews/{review_id}", map[string]interface{}{"plugin_id": plugin_id, "review_id": review_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, review_update, &result)
+		return result, err
 }
 // Delete{plugin_id}reviews{review_id} DELETE /{plugin_id}/reviews/{review_id}
 func (c *PluginMarketplaceClient) Delete{plugin_id}reviews{review_id}(plugin_id string, review_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews/{review_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"review_id": review_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Delete{plugin_id}reviews{review_id}WithContext(context.Background(), plugin_id, review_id)
+}
+// Delete{plugin_id}reviews{review_id}WithContext DELETE /{plugin_id}/reviews/{review_id}
+func (c *PluginMarketplaceClient) Delete{plugin_id}reviews{review_id}WithContext(ctx context.Context, plugin_id string, review_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews/{review_id}", map[string]interface{}{"plugin_id": plugin_id, "review_id": review_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Post{plugin_id}reviews{review_id}helpful POST /{plugin_id}/reviews/{review_id}/helpful
 func (c *PluginMarketplaceClient) Post{plugin_id}reviews{review_id}helpful(plugin_id string, review_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{plugin_id}/reviews/{review_id}/helpful", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"plugin_id": plugin_id,
-			"review_id": review_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{plugin_id}reviews{review_id}helpfulWithContext(context.Background(), plugin_id, review_id)
+}
+// Post{plugin_id}reviews{review_id}helpfulWithContext POST /{plugin_id}/reviews/{review_id}/helpful
+func (c *PluginMarketplaceClient) Post{plugin_id}reviews{review_id}helpfulWithContext(ctx context.Context, plugin_id string, review_id string) (interface{}, error) {
+		path, err := expandPath("/{plugin_id}/reviews/{review_id}/helpful", map[string]interface{}{"plugin_id": plugin_id, "review_id": review_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
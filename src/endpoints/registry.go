@@ -1,12 +1,8 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"io"
-	"net/http"
-	"net/url"
 )
 
 // RegistryClient handles registry API calls
@@ -16,660 +12,227 @@ type RegistryClient struct {
 
 // Getmarketplace GET /marketplace
 func (c *RegistryClient) Getmarketplace() (interface{}, error) {
-		url := fmt.Sprintf("/marketplace", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetmarketplaceWithContext(context.Background())
+}
+// GetmarketplaceWithContext GET /marketplace
+func (c *RegistryClient) GetmarketplaceWithContext(ctx context.Context) (interface{}, error) {
+		path := "/marketplace"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getcategories GET /categories
 func (c *RegistryClient) Getcategories() (interface{}, error) {
-		url := fmt.Sprintf("/categories", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetcategoriesWithContext(context.Background())
+}
+// GetcategoriesWithContext GET /categories
+func (c *RegistryClient) GetcategoriesWithContext(ctx context.Context) (interface{}, error) {
+		path := "/categories"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postbundles POST /bundles
 func (c *RegistryClient) Postbundles(bundle interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(bundle)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostbundlesWithContext(context.Background(), bundle)
+}
+// PostbundlesWithContext POST /bundles
+func (c *RegistryClient) PostbundlesWithContext(ctx context.Context, bundle interface{}) (interface{}, error) {
+		path := "/bundles"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, bundle, &result)
+		return result, err
 }
 // Getbundles{bundle_id} GET /bundles/{bundle_id}
 func (c *RegistryClient) Getbundles{bundle_id}(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getbundles{bundle_id}WithContext(context.Background(), bundle_id)
+}
+// Getbundles{bundle_id}WithContext GET /bundles/{bundle_id}
+func (c *RegistryClient) Getbundles{bundle_id}WithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Putbundles{bundle_id} PUT /bundles/{bundle_id}
 func (c *RegistryClient) Putbundles{bundle_id}(bundle_id string, bundle_update interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"bundle_id": bundle_id,
-			"bundle_update": bundle_update,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Putbundles{bundle_id}WithContext(context.Background(), bundle_id, bundle_update)
+}
+// Putbundles{bundle_id}WithContext PUT /bundles/{bundle_id}
+func (c *RegistryClient) Putbundles{bundle_id}WithContext(ctx context.Context, bundle_id string, bundle_update interface{}) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, bundle_update, &result)
+		return result, err
 }
 // Deletebundles{bundle_id} DELETE /bundles/{bundle_id}
 func (c *RegistryClient) Deletebundles{bundle_id}(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(bundle_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Deletebundles{bundle_id}WithContext(context.Background(), bundle_id)
+}
+// Deletebundles{bundle_id}WithContext DELETE /bundles/{bundle_id}
+func (c *RegistryClient) Deletebundles{bundle_id}WithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Getbundles{bundle_id}download GET /bundles/{bundle_id}/download
-func (c *RegistryClient) Getbundles{bundle_id}download(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/download", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
+func (c *RegistryClient) Getbundles{bundle_id}download(bundle_id string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+		return c.Getbundles{bundle_id}downloadWithContext(context.Background(), bundle_id, w, opts)
+}
+// Getbundles{bundle_id}downloadWithContext GET /bundles/{bundle_id}/download
+func (c *RegistryClient) Getbundles{bundle_id}downloadWithContext(ctx context.Context, bundle_id string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+		path, err := expandPath("/bundles/{bundle_id}/download", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		return c.client.download(ctx, path, nil, w, newDownloadState(0, nil), opts)
 }
 // Postbundles{bundle_id}install POST /bundles/{bundle_id}/install
 func (c *RegistryClient) Postbundles{bundle_id}install(bundle_id string, installation interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/install", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"bundle_id": bundle_id,
-			"installation": installation,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postbundles{bundle_id}installWithContext(context.Background(), bundle_id, installation)
+}
+// Postbundles{bundle_id}installWithContext POST /bundles/{bundle_id}/install
+func (c *RegistryClient) Postbundles{bundle_id}installWithContext(ctx context.Context, bundle_id string, installation interface{}) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/install", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, installation, &result)
+		return result, err
 }
 // Getmybundles GET /my-bundles
 func (c *RegistryClient) Getmybundles() (interface{}, error) {
-		url := fmt.Sprintf("/my-bundles", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetmybundlesWithContext(context.Background())
+}
+// GetmybundlesWithContext GET /my-bundles
+func (c *RegistryClient) GetmybundlesWithContext(ctx context.Context) (interface{}, error) {
+		path := "/my-bundles"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postexecutecoderule POST /execute/code-rule
 func (c *RegistryClient) Postexecutecoderule() (interface{}, error) {
-		url := fmt.Sprintf("/execute/code-rule", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostexecutecoderuleWithContext(context.Background())
+}
+// PostexecutecoderuleWithContext POST /execute/code-rule
+func (c *RegistryClient) PostexecutecoderuleWithContext(ctx context.Context) (interface{}, error) {
+		path := "/execute/code-rule"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Getexecutions{execution_id} GET /executions/{execution_id}
 func (c *RegistryClient) Getexecutions{execution_id}(execution_id string) (interface{}, error) {
-		url := fmt.Sprintf("/executions/{execution_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("execution_id", fmt.Sprintf("%v", execution_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getexecutions{execution_id}WithContext(context.Background(), execution_id)
+}
+// Getexecutions{execution_id}WithContext GET /executions/{execution_id}
+func (c *RegistryClient) Getexecutions{execution_id}WithContext(ctx context.Context, execution_id string) (interface{}, error) {
+		path, err := expandPath("/executions/{execution_id}", map[string]interface{}{"execution_id": execution_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getmyexecutions GET /my-executions
 func (c *RegistryClient) Getmyexecutions(page *float64, per_page *float64) (interface{}, error) {
-		url := fmt.Sprintf("/my-executions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("per_page", fmt.Sprintf("%v", per_page))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetmyexecutionsWithContext(context.Background(), page, per_page)
+}
+// GetmyexecutionsWithContext GET /my-executions
+func (c *RegistryClient) GetmyexecutionsWithContext(ctx context.Context, page *float64, per_page *float64) (interface{}, error) {
+		path := "/my-executions"
+		params := newQuery().
+			Add("page", page).
+			Add("per_page", per_page).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Postbundles{bundle_id}rate POST /bundles/{bundle_id}/rate
 func (c *RegistryClient) Postbundles{bundle_id}rate(bundle_id string, rating interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/rate", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"bundle_id": bundle_id,
-			"rating": rating,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postbundles{bundle_id}rateWithContext(context.Background(), bundle_id, rating)
+}
+// Postbundles{bundle_id}rateWithContext POST /bundles/{bundle_id}/rate
+func (c *RegistryClient) Postbundles{bundle_id}rateWithContext(ctx context.Context, bundle_id string, rating interface{}) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/rate", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, rating, &result)
+		return result, err
 }
 // Postbundles{bundle_id}review POST /bundles/{bundle_id}/review
 func (c *RegistryClient) Postbundles{bundle_id}review(bundle_id string, review interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/review", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"bundle_id": bundle_id,
-			"review": review,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postbundles{bundle_id}reviewWithContext(context.Background(), bundle_id, review)
+}
+// Postbundles{bundle_id}reviewWithContext POST /bundles/{bundle_id}/review
+func (c *RegistryClient) Postbundles{bundle_id}reviewWithContext(ctx context.Context, bundle_id string, review interface{}) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/review", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, review, &result)
+		return result, err
 }
 // Getbundles{bundle_id}reviews GET /bundles/{bundle_id}/reviews
 func (c *RegistryClient) Getbundles{bundle_id}reviews(bundle_id string, page *float64, per_page *float64) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/reviews", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("per_page", fmt.Sprintf("%v", per_page))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getbundles{bundle_id}reviewsWithContext(context.Background(), bundle_id, page, per_page)
+}
+// Getbundles{bundle_id}reviewsWithContext GET /bundles/{bundle_id}/reviews
+func (c *RegistryClient) Getbundles{bundle_id}reviewsWithContext(ctx context.Context, bundle_id string, page *float64, per_page *float64) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/reviews", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("page", page).
+			Add("per_page", per_page).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getbundles{bundle_id}versions GET /bundles/{bundle_id}/versions
 func (c *RegistryClient) Getbundles{bundle_id}versions(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/versions", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getbundles{bundle_id}versionsWithContext(context.Background(), bundle_id)
+}
+// Getbundles{bundle_id}versionsWithContext GET /bundles/{bundle_id}/versions
+func (c *RegistryClient) Getbundles{bundle_id}versionsWithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/versions", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Getbundles{bundle_id}changelog GET /bundles/{bundle_id}/changelog
 func (c *RegistryClient) Getbundles{bundle_id}changelog(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/changelog", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getbundles{bundle_id}changelogWithContext(context.Background(), bundle_id)
+}
+// Getbundles{bundle_id}changelogWithContext GET /bundles/{bundle_id}/changelog
+func (c *RegistryClient) Getbundles{bundle_id}changelogWithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/changelog", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // RepositoriesClient handles repositories API calls
@@ -16,518 +11,200 @@ type RepositoriesClient struct {
 
 // Postsync POST /sync
 func (c *RepositoriesClient) Postsync(background_tasks interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/sync", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(background_tasks)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostsyncWithContext(context.Background(), background_tasks)
+}
+// PostsyncWithContext POST /sync
+func (c *RepositoriesClient) PostsyncWithContext(ctx context.Context, background_tasks interface{}) (interface{}, error) {
+		path := "/sync"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, background_tasks, &result)
+		return result, err
 }
 // GetRoot GET /
 func (c *RepositoriesClient) GetRoot(connection_id *string, language *string, scan_enabled *bool, search *string, page *float64, per_page *float64) (interface{}, error) {
-		url := fmt.Sprintf("/", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("connection_id", fmt.Sprintf("%v", connection_id))
-		params.Add("language", fmt.Sprintf("%v", language))
-		params.Add("scan_enabled", fmt.Sprintf("%v", scan_enabled))
-		params.Add("search", fmt.Sprintf("%v", search))
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("per_page", fmt.Sprintf("%v", per_page))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetRootWithContext(context.Background(), connection_id, language, scan_enabled, search, page, per_page)
+}
+// GetRootWithContext GET /
+func (c *RepositoriesClient) GetRootWithContext(ctx context.Context, connection_id *string, language *string, scan_enabled *bool, search *string, page *float64, per_page *float64) (interface{}, error) {
+		path := "/"
+		params := newQuery().
+			Add("connection_id", connection_id).
+			Add("language", language).
+			Add("scan_enabled", scan_enabled).
+			Add("search", search).
+			Add("page", page).
+			Add("per_page", per_page).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{repository_id} GET /{repository_id}
 func (c *RepositoriesClient) Get{repository_id}(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}WithContext(context.Background(), repository_id)
+}
+// Get{repository_id}WithContext GET /{repository_id}
+func (c *RepositoriesClient) Get{repository_id}WithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Put{repository_id} PUT /{repository_id}
 func (c *RepositoriesClient) Put{repository_id}(repository_id string, repository_update interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"repository_id": repository_id,
-			"repository_update": repository_update,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Put{repository_id}WithContext(context.Background(), repository_id, repository_update)
+}
+// Put{repository_id}WithContext PUT /{repository_id}
+func (c *RepositoriesClient) Put{repository_id}WithContext(ctx context.Context, repository_id string, repository_update interface{}) (interface{}, error) {
+		path, err := expandPath("/{repository_id}", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, repository_update, &result)
+		return result, err
 }
 // Delete{repository_id} DELETE /{repository_id}
 func (c *RepositoriesClient) Delete{repository_id}(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(repository_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Delete{repository_id}WithContext(context.Background(), repository_id)
+}
+// Delete{repository_id}WithContext DELETE /{repository_id}
+func (c *RepositoriesClient) Delete{repository_id}WithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Get{repository_id}scans GET /{repository_id}/scans
 func (c *RepositoriesClient) Get{repository_id}scans(repository_id string, limit *float64) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/scans", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}scansWithContext(context.Background(), repository_id, limit)
+}
+// Get{repository_id}scansWithContext GET /{repository_id}/scans
+func (c *RepositoriesClient) Get{repository_id}scansWithContext(ctx context.Context, repository_id string, limit *float64) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/scans", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("limit", limit).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Post{repository_id}scan POST /{repository_id}/scan
 func (c *RepositoriesClient) Post{repository_id}scan(repository_id string, background_tasks interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/scan", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"repository_id": repository_id,
-			"background_tasks": background_tasks,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		return c.Post{repository_id}scanWithContext(context.Background(), repository_id, background_tasks)
+}
+// Post{repository_id}scanWithContext POST /{repository_id}/scan
+func (c *RepositoriesClient) Post{repository_id}scanWithContext(ctx context.Context, repository_id string, background_tasks interface{}) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/scan", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		body := map[string]interface{}{
+			"background_tasks": background_tasks,
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Get{repository_id}branches GET /{repository_id}/branches
 func (c *RepositoriesClient) Get{repository_id}branches(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/branches", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}branchesWithContext(context.Background(), repository_id)
+}
+// Get{repository_id}branchesWithContext GET /{repository_id}/branches
+func (c *RepositoriesClient) Get{repository_id}branchesWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/branches", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Post{repository_id}pause POST /{repository_id}/pause
 func (c *RepositoriesClient) Post{repository_id}pause(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/pause", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(repository_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{repository_id}pauseWithContext(context.Background(), repository_id)
+}
+// Post{repository_id}pauseWithContext POST /{repository_id}/pause
+func (c *RepositoriesClient) Post{repository_id}pauseWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/pause", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Post{repository_id}resume POST /{repository_id}/resume
 func (c *RepositoriesClient) Post{repository_id}resume(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/resume", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(repository_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{repository_id}resumeWithContext(context.Background(), repository_id)
+}
+// Post{repository_id}resumeWithContext POST /{repository_id}/resume
+func (c *RepositoriesClient) Post{repository_id}resumeWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/resume", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Get{repository_id}analytics GET /{repository_id}/analytics
 func (c *RepositoriesClient) Get{repository_id}analytics(repository_id string, timeframe *string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/analytics", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		params.Add("timeframe", fmt.Sprintf("%v", timeframe))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}analyticsWithContext(context.Background(), repository_id, timeframe)
+}
+// Get{repository_id}analyticsWithContext GET /{repository_id}/analytics
+func (c *RepositoriesClient) Get{repository_id}analyticsWithContext(ctx context.Context, repository_id string, timeframe *string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/analytics", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("timeframe", timeframe).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{repository_id}badge GET /{repository_id}/badge
 func (c *RepositoriesClient) Get{repository_id}badge(repository_id string, style *string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/badge", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		params.Add("style", fmt.Sprintf("%v", style))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}badgeWithContext(context.Background(), repository_id, style)
+}
+// Get{repository_id}badgeWithContext GET /{repository_id}/badge
+func (c *RepositoriesClient) Get{repository_id}badgeWithContext(ctx context.Context, repository_id string, style *string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/badge", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("style", style).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{repository_id}activity GET /{repository_id}/activity
 func (c *RepositoriesClient) Get{repository_id}activity(repository_id string, limit *float64) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/activity", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}activityWithContext(context.Background(), repository_id, limit)
+}
+// Get{repository_id}activityWithContext GET /{repository_id}/activity
+func (c *RepositoriesClient) Get{repository_id}activityWithContext(ctx context.Context, repository_id string, limit *float64) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/activity", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("limit", limit).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
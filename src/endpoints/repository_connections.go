@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // RepositoryConnectionsClient handles repository_connections API calls
@@ -16,278 +11,98 @@ type RepositoryConnectionsClient struct {
 
 // PostRoot POST /
 func (c *RepositoryConnectionsClient) PostRoot(connection_in interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(connection_in)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostRootWithContext(context.Background(), connection_in)
+}
+// PostRootWithContext POST /
+func (c *RepositoryConnectionsClient) PostRootWithContext(ctx context.Context, connection_in interface{}) (interface{}, error) {
+		path := "/"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, connection_in, &result)
+		return result, err
 }
 // GetRoot GET /
 func (c *RepositoryConnectionsClient) GetRoot(provider_id *string, connection_type *string, is_active *bool) (interface{}, error) {
-		url := fmt.Sprintf("/", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("provider_id", fmt.Sprintf("%v", provider_id))
-		params.Add("connection_type", fmt.Sprintf("%v", connection_type))
-		params.Add("is_active", fmt.Sprintf("%v", is_active))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetRootWithContext(context.Background(), provider_id, connection_type, is_active)
+}
+// GetRootWithContext GET /
+func (c *RepositoryConnectionsClient) GetRootWithContext(ctx context.Context, provider_id *string, connection_type *string, is_active *bool) (interface{}, error) {
+		path := "/"
+		params := newQuery().
+			Add("provider_id", provider_id).
+			Add("connection_type", connection_type).
+			Add("is_active", is_active).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{connection_id} GET /{connection_id}
 func (c *RepositoryConnectionsClient) Get{connection_id}(connection_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{connection_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("connection_id", fmt.Sprintf("%v", connection_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{connection_id}WithContext(context.Background(), connection_id)
+}
+// Get{connection_id}WithContext GET /{connection_id}
+func (c *RepositoryConnectionsClient) Get{connection_id}WithContext(ctx context.Context, connection_id string) (interface{}, error) {
+		path, err := expandPath("/{connection_id}", map[string]interface{}{"connection_id": connection_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Put{connection_id} PUT /{connection_id}
 func (c *RepositoryConnectionsClient) Put{connection_id}(connection_id string, connection_update interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/{connection_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"connection_id": connection_id,
-			"connection_update": connection_update,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Put{connection_id}WithContext(context.Background(), connection_id, connection_update)
+}
+// Put{connection_id}WithContext PUT /{connection_id}
+func (c *RepositoryConnectionsClient) Put{connection_id}WithContext(ctx context.Context, connection_id string, connection_update interface{}) (interface{}, error) {
+		path, err := expandPath("/{connection_id}", map[string]interface{}{"connection_id": connection_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, connection_update, &result)
+		return result, err
 }
 // Delete{connection_id} DELETE /{connection_id}
 func (c *RepositoryConnectionsClient) Delete{connection_id}(connection_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{connection_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(connection_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Delete{connection_id}WithContext(context.Background(), connection_id)
+}
+// Delete{connection_id}WithContext DELETE /{connection_id}
+func (c *RepositoryConnectionsClient) Delete{connection_id}WithContext(ctx context.Context, connection_id string) (interface{}, error) {
+		path, err := expandPath("/{connection_id}", map[string]interface{}{"connection_id": connection_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Post{connection_id}validate POST /{connection_id}/validate
 func (c *RepositoryConnectionsClient) Post{connection_id}validate(connection_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{connection_id}/validate", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(connection_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{connection_id}validateWithContext(context.Background(), connection_id)
+}
+// Post{connection_id}validateWithContext POST /{connection_id}/validate
+func (c *RepositoryConnectionsClient) Post{connection_id}validateWithContext(ctx context.Context, connection_id string) (interface{}, error) {
+		path, err := expandPath("/{connection_id}/validate", map[string]interface{}{"connection_id": connection_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Post{connection_id}refresh POST /{connection_id}/refresh
 func (c *RepositoryConnectionsClient) Post{connection_id}refresh(connection_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{connection_id}/refresh", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(connection_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{connection_id}refreshWithContext(context.Background(), connection_id)
+}
+// Post{connection_id}refreshWithContext POST /{connection_id}/refresh
+func (c *RepositoryConnectionsClient) Post{connection_id}refreshWithContext(ctx context.Context, connection_id string) (interface{}, error) {
+		path, err := expandPath("/{connection_id}/refresh", map[string]interface{}{"connection_id": connection_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // RepositoryWebhooksClient handles repository_webhooks API calls
@@ -16,161 +11,59 @@ type RepositoryWebhooksClient struct {
 
 // Postgithub POST /github
 func (c *RepositoryWebhooksClient) Postgithub(x_hub_signature_256 *string, x_github_event *string, x_github_delivery *string) (interface{}, error) {
-		url := fmt.Sprintf("/github", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
+		return c.PostgithubWithContext(context.Background(), x_hub_signature_256, x_github_event, x_github_delivery)
+}
+// PostgithubWithContext POST /github
+func (c *RepositoryWebhooksClient) PostgithubWithContext(ctx context.Context, x_hub_signature_256 *string, x_github_event *string, x_github_delivery *string) (interface{}, error) {
+		path := "/github"
+		body := map[string]interface{}{
 			"x_hub_signature_256": x_hub_signature_256,
 			"x_github_event": x_github_event,
 			"x_github_delivery": x_github_delivery,
 		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Post{repository_id}setup POST /{repository_id}/setup
 func (c *RepositoryWebhooksClient) Post{repository_id}setup(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/setup", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(repository_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Post{repository_id}setupWithContext(context.Background(), repository_id)
+}
+// Post{repository_id}setupWithContext POST /{repository_id}/setup
+func (c *RepositoryWebhooksClient) Post{repository_id}setupWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/setup", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Get{repository_id}status GET /{repository_id}/status
 func (c *RepositoryWebhooksClient) Get{repository_id}status(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/status", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Get{repository_id}statusWithContext(context.Background(), repository_id)
+}
+// Get{repository_id}statusWithContext GET /{repository_id}/status
+func (c *RepositoryWebhooksClient) Get{repository_id}statusWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/status", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Delete{repository_id}webhook DELETE /{repository_id}/webhook
 func (c *RepositoryWebhooksClient) Delete{repository_id}webhook(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{repository_id}/webhook", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(repository_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Delete{repository_id}webhookWithContext(context.Background(), repository_id)
+}
+// Delete{repository_id}webhookWithContext DELETE /{repository_id}/webhook
+func (c *RepositoryWebhooksClient) Delete{repository_id}webhookWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/{repository_id}/webhook", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
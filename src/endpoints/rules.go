@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // RulesClient handles rules API calls
@@ -16,434 +11,156 @@ type RulesClient struct {
 
 // Getbundles GET /bundles
 func (c *RulesClient) Getbundles(category *string, official_only *bool, page *float64, per_page *float64) (interface{}, error) {
-		url := fmt.Sprintf("/bundles", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("category", fmt.Sprintf("%v", category))
-		params.Add("official_only", fmt.Sprintf("%v", official_only))
-		params.Add("page", fmt.Sprintf("%v", page))
-		params.Add("per_page", fmt.Sprintf("%v", per_page))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetbundlesWithContext(context.Background(), category, official_only, page, per_page)
+}
+// GetbundlesWithContext GET /bundles
+func (c *RulesClient) GetbundlesWithContext(ctx context.Context, category *string, official_only *bool, page *float64, per_page *float64) (interface{}, error) {
+		path := "/bundles"
+		params := newQuery().
+			Add("category", category).
+			Add("official_only", official_only).
+			Add("page", page).
+			Add("per_page", per_page).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Postbundles{bundle_id}install POST /bundles/{bundle_id}/install
 func (c *RulesClient) Postbundles{bundle_id}install(bundle_id string, installation interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/install", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"bundle_id": bundle_id,
-			"installation": installation,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postbundles{bundle_id}installWithContext(context.Background(), bundle_id, installation)
+}
+// Postbundles{bundle_id}installWithContext POST /bundles/{bundle_id}/install
+func (c *RulesClient) Postbundles{bundle_id}installWithContext(ctx context.Context, bundle_id string, installation interface{}) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/install", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, installation, &result)
+		return result, err
 }
 // Getbundles{bundle_id}rules GET /bundles/{bundle_id}/rules
 func (c *RulesClient) Getbundles{bundle_id}rules(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/rules", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("bundle_id", fmt.Sprintf("%v", bundle_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getbundles{bundle_id}rulesWithContext(context.Background(), bundle_id)
+}
+// Getbundles{bundle_id}rulesWithContext GET /bundles/{bundle_id}/rules
+func (c *RulesClient) Getbundles{bundle_id}rulesWithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/rules", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postvalidate POST /validate
 func (c *RulesClient) Postvalidate() (interface{}, error) {
-		url := fmt.Sprintf("/validate", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostvalidateWithContext(context.Background())
+}
+// PostvalidateWithContext POST /validate
+func (c *RulesClient) PostvalidateWithContext(ctx context.Context) (interface{}, error) {
+		path := "/validate"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Getupdates GET /updates
 func (c *RulesClient) Getupdates() (interface{}, error) {
-		url := fmt.Sprintf("/updates", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetupdatesWithContext(context.Background())
+}
+// GetupdatesWithContext GET /updates
+func (c *RulesClient) GetupdatesWithContext(ctx context.Context) (interface{}, error) {
+		path := "/updates"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Deletebundles{bundle_id}install DELETE /bundles/{bundle_id}/install
 func (c *RulesClient) Deletebundles{bundle_id}install(bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/bundles/{bundle_id}/install", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(bundle_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Deletebundles{bundle_id}installWithContext(context.Background(), bundle_id)
+}
+// Deletebundles{bundle_id}installWithContext DELETE /bundles/{bundle_id}/install
+func (c *RulesClient) Deletebundles{bundle_id}installWithContext(ctx context.Context, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/bundles/{bundle_id}/install", map[string]interface{}{"bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Getorganizations{organization_id}bundles GET /organizations/{organization_id}/bundles
 func (c *RulesClient) Getorganizations{organization_id}bundles(organization_id string) (interface{}, error) {
-		url := fmt.Sprintf("/organizations/{organization_id}/bundles", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getorganizations{organization_id}bundlesWithContext(context.Background(), organization_id)
+}
+// Getorganizations{organization_id}bundlesWithContext GET /organizations/{organization_id}/bundles
+func (c *RulesClient) Getorganizations{organization_id}bundlesWithContext(ctx context.Context, organization_id string) (interface{}, error) {
+		path, err := expandPath("/organizations/{organization_id}/bundles", map[string]interface{}{"organization_id": organization_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postorganizations{organization_id}bundles{bundle_id}install POST /organizations/{organization_id}/bundles/{bundle_id}/install
 func (c *RulesClient) Postorganizations{organization_id}bundles{bundle_id}install(organization_id string, bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/organizations/{organization_id}/bundles/{bundle_id}/install", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"organization_id": organization_id,
-			"bundle_id": bundle_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Postorganizations{organization_id}bundles{bundle_id}installWithContext(context.Background(), organization_id, bundle_id)
+}
+// Postorganizations{organization_id}bundles{bundle_id}installWithContext POST /organizations/{organization_id}/bundles/{bundle_id}/install
+func (c *RulesClient) Postorganizations{organization_id}bundles{bundle_id}installWithContext(ctx context.Context, organization_id string, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/organizations/{organization_id}/bundles/{bundle_id}/install", map[string]interface{}{"organization_id": organization_id, "bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Deleteorganizations{organization_id}bundles{bundle_id} DELETE /organizations/{organization_id}/bundles/{bundle_id}
 func (c *RulesClient) Deleteorganizations{organization_id}bundles{bundle_id}(organization_id string, bundle_id string) (interface{}, error) {
-		url := fmt.Sprintf("/organizations/{organization_id}/bundles/{bundle_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"organization_id": organization_id,
-			"bundle_id": bundle_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Deleteorganizations{organization_id}bundles{bundle_id}WithContext(context.Background(), organization_id, bundle_id)
+}
+// Deleteorganizations{organization_id}bundles{bundle_id}WithContext DELETE /organizations/{organization_id}/bundles/{bundle_id}
+func (c *RulesClient) Deleteorganizations{organization_id}bundles{bundle_id}WithContext(ctx context.Context, organization_id string, bundle_id string) (interface{}, error) {
+		path, err := expandPath("/organizations/{organization_id}/bundles/{bundle_id}", map[string]interface{}{"organization_id": organization_id, "bundle_id": bundle_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
 // Getorganizations{organization_id}rules GET /organizations/{organization_id}/rules
 func (c *RulesClient) Getorganizations{organization_id}rules(organization_id string, category *string, severity *string) (interface{}, error) {
-		url := fmt.Sprintf("/organizations/{organization_id}/rules", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		params.Add("category", fmt.Sprintf("%v", category))
-		params.Add("severity", fmt.Sprintf("%v", severity))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getorganizations{organization_id}rulesWithContext(context.Background(), organization_id, category, severity)
+}
+// Getorganizations{organization_id}rulesWithContext GET /organizations/{organization_id}/rules
+func (c *RulesClient) Getorganizations{organization_id}rulesWithContext(ctx context.Context, organization_id string, category *string, severity *string) (interface{}, error) {
+		path, err := expandPath("/organizations/{organization_id}/rules", map[string]interface{}{"organization_id": organization_id})
 		if err != nil {
 			return nil, err
 		}
+		params := newQuery().
+			Add("category", category).
+			Add("severity", severity).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getorganizations{organization_id}rulesstats GET /organizations/{organization_id}/rules/stats
 func (c *RulesClient) Getorganizations{organization_id}rulesstats(organization_id string) (interface{}, error) {
-		url := fmt.Sprintf("/organizations/{organization_id}/rules/stats", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getorganizations{organization_id}rulesstatsWithContext(context.Background(), organization_id)
+}
+// Getorganizations{organization_id}rulesstatsWithContext GET /organizations/{organization_id}/rules/stats
+func (c *RulesClient) Getorganizations{organization_id}rulesstatsWithContext(ctx context.Context, organization_id string) (interface{}, error) {
+		path, err := expandPath("/organizations/{organization_id}/rules/stats", map[string]interface{}{"organization_id": organization_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
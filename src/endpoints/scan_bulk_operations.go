@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // ScanBulkOperationsClient handles scan_bulk_operations API calls
@@ -16,159 +11,51 @@ type ScanBulkOperationsClient struct {
 
 // Postbulkinitiate POST /bulk/initiate
 func (c *ScanBulkOperationsClient) Postbulkinitiate(scan_requests []interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/initiate", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_requests)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostbulkinitiateWithContext(context.Background(), scan_requests)
+}
+// PostbulkinitiateWithContext POST /bulk/initiate
+func (c *ScanBulkOperationsClient) PostbulkinitiateWithContext(ctx context.Context, scan_requests []interface{}) (interface{}, error) {
+		path := "/bulk/initiate"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, scan_requests, &result)
+		return result, err
 }
 // Postbulkcancel POST /bulk/cancel
 func (c *ScanBulkOperationsClient) Postbulkcancel(scan_ids []string) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/cancel", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_ids)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostbulkcancelWithContext(context.Background(), scan_ids)
+}
+// PostbulkcancelWithContext POST /bulk/cancel
+func (c *ScanBulkOperationsClient) PostbulkcancelWithContext(ctx context.Context, scan_ids []string) (interface{}, error) {
+		path := "/bulk/cancel"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, scan_ids, &result)
+		return result, err
 }
 // Deletebulkdelete DELETE /bulk/delete
 func (c *ScanBulkOperationsClient) Deletebulkdelete(scan_ids []string) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/delete", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_ids)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.DeletebulkdeleteWithContext(context.Background(), scan_ids)
+}
+// DeletebulkdeleteWithContext DELETE /bulk/delete
+func (c *ScanBulkOperationsClient) DeletebulkdeleteWithContext(ctx context.Context, scan_ids []string) (interface{}, error) {
+		path := "/bulk/delete"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "DELETE", path, nil, scan_ids, &result)
+		return result, err
 }
 // Getbulkstatus GET /bulk/status
 func (c *ScanBulkOperationsClient) Getbulkstatus(scan_ids *[]string, organization_id *string, status_filter *string, limit *float64) (interface{}, error) {
-		url := fmt.Sprintf("/bulk/status", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("scan_ids", fmt.Sprintf("%v", scan_ids))
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		params.Add("status_filter", fmt.Sprintf("%v", status_filter))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetbulkstatusWithContext(context.Background(), scan_ids, organization_id, status_filter, limit)
+}
+// GetbulkstatusWithContext GET /bulk/status
+func (c *ScanBulkOperationsClient) GetbulkstatusWithContext(ctx context.Context, scan_ids *[]string, organization_id *string, status_filter *string, limit *float64) (interface{}, error) {
+		path := "/bulk/status"
+		params := newQuery().
+			Add("scan_ids", scan_ids).
+			Add("organization_id", organization_id).
+			Add("status_filter", status_filter).
+			Add("limit", limit).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
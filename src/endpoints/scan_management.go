@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // ScanManagementClient handles scan_management API calls
@@ -15,200 +10,92 @@ type ScanManagementClient struct {
 }
 
 // PostRoot POST /
-func (c *ScanManagementClient) PostRoot(scan_in interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_in)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
+func (c *ScanManagementClient) PostRoot(scan_in *ScanCreateRequest) (*Scan, error) {
+		return c.PostRootWithContext(context.Background(), scan_in)
+}
+// PostRootWithContext POST /
+func (c *ScanManagementClient) PostRootWithContext(ctx context.Context, scan_in *ScanCreateRequest) (*Scan, error) {
+		path := "/"
+		var result Scan
+		err := c.client.do(ctx, "POST", path, nil, scan_in, &result)
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		return &result, nil
 }
 // GetRoot GET /
-func (c *ScanManagementClient) GetRoot(skip *float64, limit *float64, status_filter *string, organization_id *string) (interface{}, error) {
-		url := fmt.Sprintf("/", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("skip", fmt.Sprintf("%v", skip))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("status_filter", fmt.Sprintf("%v", status_filter))
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+func (c *ScanManagementClient) GetRoot(skip *float64, limit *float64, status_filter *string, organization_id *string) ([]Scan, error) {
+		return c.GetRootWithContext(context.Background(), skip, limit, status_filter, organization_id)
+}
+// GetRootWithContext GET /
+func (c *ScanManagementClient) GetRootWithContext(ctx context.Context, skip *float64, limit *float64, status_filter *string, organization_id *string) ([]Scan, error) {
+		path := "/"
+		params := newQuery().
+			Add("skip", skip).
+			Add("limit", limit).
+			Add("status_filter", status_filter).
+			Add("organization_id", organization_id).
+			Values()
+		var result []Scan
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Get{scan_id:uuid} GET /{scan_id:uuid}
-func (c *ScanManagementClient) Get{scan_id:uuid}(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{scan_id:uuid}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("scan_id", fmt.Sprintf("%v", scan_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+func (c *ScanManagementClient) Get{scan_id:uuid}(scan_id string) (*Scan, error) {
+		return c.Get{scan_id:uuid}WithContext(context.Background(), scan_id)
+}
+// Get{scan_id:uuid}WithContext GET /{scan_id:uuid}
+func (c *ScanManagementClient) Get{scan_id:uuid}WithContext(ctx context.Context, scan_id string) (*Scan, error) {
+		path, err := expandPath("/{scan_id:uuid}", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
+		var result Scan
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		return &result, nil
 }
 // Get{scan_id:uuid}results GET /{scan_id:uuid}/results
-func (c *ScanManagementClient) Get{scan_id:uuid}results(scan_id string, severity_filter *string, rule_type_filter *string, limit *float64) (interface{}, error) {
-		url := fmt.Sprintf("/{scan_id:uuid}/results", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("scan_id", fmt.Sprintf("%v", scan_id))
-		params.Add("severity_filter", fmt.Sprintf("%v", severity_filter))
-		params.Add("rule_type_filter", fmt.Sprintf("%v", rule_type_filter))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
+func (c *ScanManagementClient) Get{scan_id:uuid}results(scan_id string, severity_filter *string, rule_type_filter *string, limit *float64) (*ScanResult, error) {
+		return c.Get{scan_id:uuid}resultsWithContext(context.Background(), scan_id, severity_filter, rule_type_filter, limit)
+}
+// Get{scan_id:uuid}resultsWithContext GET /{scan_id:uuid}/results
+func (c *ScanManagementClient) Get{scan_id:uuid}resultsWithContext(ctx context.Context, scan_id string, severity_filter *string, rule_type_filter *string, limit *float64) (*ScanResult, error) {
+		path, err := expandPath("/{scan_id:uuid}/results", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
+		params := newQuery().
+			Add("severity_filter", severity_filter).
+			Add("rule_type_filter", rule_type_filter).
+			Add("limit", limit).
+			Values()
+		var result ScanResult
+		err = c.client.do(ctx, "GET", path, params, nil, &result)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		if result.ScanID == "" {
+			result.ScanID = scan_id
 		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		return &result, nil
 }
 // Post{scan_id:uuid}cancel POST /{scan_id:uuid}/cancel
-func (c *ScanManagementClient) Post{scan_id:uuid}cancel(scan_id string) (interface{}, error) {
-		url := fmt.Sprintf("/{scan_id:uuid}/cancel", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(scan_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+func (c *ScanManagementClient) Post{scan_id:uuid}cancel(scan_id string) (*ScanCancelResponse, error) {
+		return c.Post{scan_id:uuid}cancelWithContext(context.Background(), scan_id)
+}
+// Post{scan_id:uuid}cancelWithContext POST /{scan_id:uuid}/cancel
+func (c *ScanManagementClient) Post{scan_id:uuid}cancelWithContext(ctx context.Context, scan_id string) (*ScanCancelResponse, error) {
+		path, err := expandPath("/{scan_id:uuid}/cancel", map[string]interface{}{"scan_id": scan_id})
 		if err != nil {
 			return nil, err
 		}
-		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
+		var result ScanCancelResponse
+		err = c.client.do(ctx, "POST", path, nil, nil, &result)
 		if err != nil {
 			return nil, err
 		}
-		return result, nil
+		return &result, nil
 }
@@ -1,12 +1,8 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"context"
 	"io"
-	"net/http"
-	"net/url"
 )
 
 // ScanRulesClient handles scan_rules API calls
@@ -16,248 +12,90 @@ type ScanRulesClient struct {
 
 // Postrules POST /rules
 func (c *ScanRulesClient) Postrules(rule_in interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/rules", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(rule_in)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostrulesWithContext(context.Background(), rule_in)
+}
+// PostrulesWithContext POST /rules
+func (c *ScanRulesClient) PostrulesWithContext(ctx context.Context, rule_in interface{}) (interface{}, error) {
+		path := "/rules"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, rule_in, &result)
+		return result, err
 }
 // Getrules GET /rules
 func (c *ScanRulesClient) Getrules(skip *float64, limit *float64, tool_filter *string, category_filter *string, severity_filter *string, language_filter *string, is_active *bool, organization_id *string) (interface{}, error) {
-		url := fmt.Sprintf("/rules", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("skip", fmt.Sprintf("%v", skip))
-		params.Add("limit", fmt.Sprintf("%v", limit))
-		params.Add("tool_filter", fmt.Sprintf("%v", tool_filter))
-		params.Add("category_filter", fmt.Sprintf("%v", category_filter))
-		params.Add("severity_filter", fmt.Sprintf("%v", severity_filter))
-		params.Add("language_filter", fmt.Sprintf("%v", language_filter))
-		params.Add("is_active", fmt.Sprintf("%v", is_active))
-		params.Add("organization_id", fmt.Sprintf("%v", organization_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GetrulesWithContext(context.Background(), skip, limit, tool_filter, category_filter, severity_filter, language_filter, is_active, organization_id)
+}
+// GetrulesWithContext GET /rules
+func (c *ScanRulesClient) GetrulesWithContext(ctx context.Context, skip *float64, limit *float64, tool_filter *string, category_filter *string, severity_filter *string, language_filter *string, is_active *bool, organization_id *string) (interface{}, error) {
+		path := "/rules"
+		params := newQuery().
+			Add("skip", skip).
+			Add("limit", limit).
+			Add("tool_filter", tool_filter).
+			Add("category_filter", category_filter).
+			Add("severity_filter", severity_filter).
+			Add("language_filter", language_filter).
+			Add("is_active", is_active).
+			Add("organization_id", organization_id).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Getrules{rule_id} GET /rules/{rule_id}
 func (c *ScanRulesClient) Getrules{rule_id}(rule_id string) (interface{}, error) {
-		url := fmt.Sprintf("/rules/{rule_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("rule_id", fmt.Sprintf("%v", rule_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getrules{rule_id}WithContext(context.Background(), rule_id)
+}
+// Getrules{rule_id}WithContext GET /rules/{rule_id}
+func (c *ScanRulesClient) Getrules{rule_id}WithContext(ctx context.Context, rule_id string) (interface{}, error) {
+		path, err := expandPath("/rules/{rule_id}", map[string]interface{}{"rule_id": rule_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postrulesupload POST /rules/upload
-func (c *ScanRulesClient) Postrulesupload(file *interface{}, organization_id *string) (interface{}, error) {
-		url := fmt.Sprintf("/rules/upload", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"file": file,
-			"organization_id": organization_id,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+func (c *ScanRulesClient) Postrulesupload(file io.Reader, filename string, organization_id *string) (interface{}, error) {
+		return c.PostrulesuploadWithContext(context.Background(), file, filename, organization_id)
+}
+// PostrulesuploadWithContext POST /rules/upload
+func (c *ScanRulesClient) PostrulesuploadWithContext(ctx context.Context, file io.Reader, filename string, organization_id *string) (interface{}, error) {
+		path := "/rules/upload"
+		body, err := newRulesUpload(file, filename, organization_id, nil)
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "POST", path, nil, body, &result)
+		return result, err
 }
 // Putrules{rule_id} PUT /rules/{rule_id}
 func (c *ScanRulesClient) Putrules{rule_id}(rule_id string, rule_update interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/rules/{rule_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"rule_id": rule_id,
-			"rule_update": rule_update,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Putrules{rule_id}WithContext(context.Background(), rule_id, rule_update)
+}
+// Putrules{rule_id}WithContext PUT /rules/{rule_id}
+func (c *ScanRulesClient) Putrules{rule_id}WithContext(ctx context.Context, rule_id string, rule_update interface{}) (interface{}, error) {
+		path, err := expandPath("/rules/{rule_id}", map[string]interface{}{"rule_id": rule_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, rule_update, &result)
+		return result, err
 }
 // Deleterules{rule_id} DELETE /rules/{rule_id}
 func (c *ScanRulesClient) Deleterules{rule_id}(rule_id string) (interface{}, error) {
-		url := fmt.Sprintf("/rules/{rule_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyBytes, err := json.Marshal(rule_id)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("DELETE", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Deleterules{rule_id}WithContext(context.Background(), rule_id)
+}
+// Deleterules{rule_id}WithContext DELETE /rules/{rule_id}
+func (c *ScanRulesClient) Deleterules{rule_id}WithContext(ctx context.Context, rule_id string) (interface{}, error) {
+		path, err := expandPath("/rules/{rule_id}", map[string]interface{}{"rule_id": rule_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "DELETE", path, nil, nil, &result)
+		return result, err
 }
@@ -1,12 +1,7 @@
 package tavo
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"context"
 )
 
 // ScanToolsClient handles scan_tools API calls
@@ -16,307 +11,110 @@ type ScanToolsClient struct {
 
 // Gettools GET /tools
 func (c *ScanToolsClient) Gettools(active_only *bool) (interface{}, error) {
-		url := fmt.Sprintf("/tools", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("active_only", fmt.Sprintf("%v", active_only))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GettoolsWithContext(context.Background(), active_only)
+}
+// GettoolsWithContext GET /tools
+func (c *ScanToolsClient) GettoolsWithContext(ctx context.Context, active_only *bool) (interface{}, error) {
+		path := "/tools"
+		params := newQuery().
+			Add("active_only", active_only).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Gettools{tool_name} GET /tools/{tool_name}
 func (c *ScanToolsClient) Gettools{tool_name}(tool_name string) (interface{}, error) {
-		url := fmt.Sprintf("/tools/{tool_name}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("tool_name", fmt.Sprintf("%v", tool_name))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Gettools{tool_name}WithContext(context.Background(), tool_name)
+}
+// Gettools{tool_name}WithContext GET /tools/{tool_name}
+func (c *ScanToolsClient) Gettools{tool_name}WithContext(ctx context.Context, tool_name string) (interface{}, error) {
+		path, err := expandPath("/tools/{tool_name}", map[string]interface{}{"tool_name": tool_name})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Gettemplates GET /templates
 func (c *ScanToolsClient) Gettemplates(tool *string, category *string, language *string, active_only *bool) (interface{}, error) {
-		url := fmt.Sprintf("/templates", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("tool", fmt.Sprintf("%v", tool))
-		params.Add("category", fmt.Sprintf("%v", category))
-		params.Add("language", fmt.Sprintf("%v", language))
-		params.Add("active_only", fmt.Sprintf("%v", active_only))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.GettemplatesWithContext(context.Background(), tool, category, language, active_only)
+}
+// GettemplatesWithContext GET /templates
+func (c *ScanToolsClient) GettemplatesWithContext(ctx context.Context, tool *string, category *string, language *string, active_only *bool) (interface{}, error) {
+		path := "/templates"
+		params := newQuery().
+			Add("tool", tool).
+			Add("category", category).
+			Add("language", language).
+			Add("active_only", active_only).
+			Values()
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "GET", path, params, nil, &result)
+		return result, err
 }
 // Gettemplates{template_id} GET /templates/{template_id}
 func (c *ScanToolsClient) Gettemplates{template_id}(template_id string) (interface{}, error) {
-		url := fmt.Sprintf("/templates/{template_id}", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("template_id", fmt.Sprintf("%v", template_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Gettemplates{template_id}WithContext(context.Background(), template_id)
+}
+// Gettemplates{template_id}WithContext GET /templates/{template_id}
+func (c *ScanToolsClient) Gettemplates{template_id}WithContext(ctx context.Context, template_id string) (interface{}, error) {
+		path, err := expandPath("/templates/{template_id}", map[string]interface{}{"template_id": template_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Postvalidateconfiguration POST /validate-configuration
 func (c *ScanToolsClient) Postvalidateconfiguration() (interface{}, error) {
-		url := fmt.Sprintf("/validate-configuration", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostvalidateconfigurationWithContext(context.Background())
+}
+// PostvalidateconfigurationWithContext POST /validate-configuration
+func (c *ScanToolsClient) PostvalidateconfigurationWithContext(ctx context.Context) (interface{}, error) {
+		path := "/validate-configuration"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
 // Getrepositories{repository_id}settings GET /repositories/{repository_id}/settings
 func (c *ScanToolsClient) Getrepositories{repository_id}settings(repository_id string) (interface{}, error) {
-		url := fmt.Sprintf("/repositories/{repository_id}/settings", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		params := url.Values{}
-		params.Add("repository_id", fmt.Sprintf("%v", repository_id))
-		fullURL += "?" + params.Encode()
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("GET", fullURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Getrepositories{repository_id}settingsWithContext(context.Background(), repository_id)
+}
+// Getrepositories{repository_id}settingsWithContext GET /repositories/{repository_id}/settings
+func (c *ScanToolsClient) Getrepositories{repository_id}settingsWithContext(ctx context.Context, repository_id string) (interface{}, error) {
+		path, err := expandPath("/repositories/{repository_id}/settings", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "GET", path, nil, nil, &result)
+		return result, err
 }
 // Putrepositories{repository_id}settings PUT /repositories/{repository_id}/settings
 func (c *ScanToolsClient) Putrepositories{repository_id}settings(repository_id string, settings interface{}) (interface{}, error) {
-		url := fmt.Sprintf("/repositories/{repository_id}/settings", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		bodyData := map[string]interface{}{
-			"repository_id": repository_id,
-			"settings": settings,
-		}
-		bodyBytes, err := json.Marshal(bodyData)
-		if err != nil {
-			return nil, err
-		}
-		body := bytes.NewReader(bodyBytes)
-		req, err := http.NewRequest("PUT", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
+		return c.Putrepositories{repository_id}settingsWithContext(context.Background(), repository_id, settings)
+}
+// Putrepositories{repository_id}settingsWithContext PUT /repositories/{repository_id}/settings
+func (c *ScanToolsClient) Putrepositories{repository_id}settingsWithContext(ctx context.Context, repository_id string, settings interface{}) (interface{}, error) {
+		path, err := expandPath("/repositories/{repository_id}/settings", map[string]interface{}{"repository_id": repository_id})
 		if err != nil {
 			return nil, err
 		}
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err = c.client.do(ctx, "PUT", path, nil, settings, &result)
+		return result, err
 }
 // Postvalidateaccess POST /validate-access
 func (c *ScanToolsClient) Postvalidateaccess() (interface{}, error) {
-		url := fmt.Sprintf("/validate-access", )
-		fullURL := c.client.baseURL + "/api/v1" + url
-		body := (*bytes.Reader)(nil)
-		req, err := http.NewRequest("POST", fullURL, body)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", "tavo-sdk-go/0.1.0")
-		if c.client.apiKey != "" {
-			req.Header.Set("X-API-Key", c.client.apiKey)
-		} else if c.client.deviceToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.client.deviceToken)
-		}
-		resp, err := c.client.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		}
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+		return c.PostvalidateaccessWithContext(context.Background())
+}
+// PostvalidateaccessWithContext POST /validate-access
+func (c *ScanToolsClient) PostvalidateaccessWithContext(ctx context.Context) (interface{}, error) {
+		path := "/validate-access"
 		var result interface{}
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
+		err := c.client.do(ctx, "POST", path, nil, nil, &result)
+		return result, err
 }
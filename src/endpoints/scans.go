@@ -0,0 +1,115 @@
+package tavo
+
+import (
+	"context"
+)
+
+// ScansClient offers typed scan operations on top of the scan_management endpoints
+type ScansClient struct {
+	client *Client
+}
+
+// ScanListOptions filters the scans returned by ListScans
+type ScanListOptions struct {
+	Status         ScanStatus
+	OrganizationID string
+
+	// Skip and Limit select a single page; use ScansPager to walk every page
+	Skip  int
+	Limit int
+}
+
+// ScanResultsOptions filters the findings returned by GetScanResults
+type ScanResultsOptions struct {
+	Severity Severity
+	RuleType string
+	Limit    int
+}
+
+// CreateScan starts a new scan
+func (c *ScansClient) CreateScan(req *ScanCreateRequest) (*Scan, error) {
+	return c.CreateScanWithContext(context.Background(), req)
+}
+
+// CreateScanWithContext starts a new scan
+func (c *ScansClient) CreateScanWithContext(ctx context.Context, req *ScanCreateRequest) (*Scan, error) {
+	return c.client.scanManagement.PostRootWithContext(ctx, req)
+}
+
+// ListScans returns one page of scans matching opts, which may be nil
+func (c *ScansClient) ListScans(opts *ScanListOptions) ([]Scan, error) {
+	return c.ListScansWithContext(context.Background(), opts)
+}
+
+// ListScansWithContext returns one page of scans matching opts, which may be nil
+func (c *ScansClient) ListScansWithContext(ctx context.Context, opts *ScanListOptions) ([]Scan, error) {
+	if opts == nil {
+		opts = &ScanListOptions{}
+	}
+	status, organizationID := opts.filters()
+	return c.client.scanManagement.GetRootWithContext(ctx, optionalInt(opts.Skip), optionalInt(opts.Limit), status, organizationID)
+}
+
+// ScansPager pages through every scan matching opts. Skip and Limit are ignored.
+func (c *ScansClient) ScansPager(opts *ScanListOptions, pagerOpts ...PagerOption) *Pager[Scan] {
+	if opts == nil {
+		opts = &ScanListOptions{}
+	}
+	status, organizationID := opts.filters()
+	return c.client.scanManagement.GetRootPager(status, organizationID, pagerOpts...)
+}
+
+// GetScan fetches a scan by ID
+func (c *ScansClient) GetScan(scanID string) (*Scan, error) {
+	return c.GetScanWithContext(context.Background(), scanID)
+}
+
+// GetScanWithContext fetches a scan by ID
+func (c *ScansClient) GetScanWithContext(ctx context.Context, scanID string) (*Scan, error) {
+	return c.client.scanManagement.Get{scan_id:uuid}WithContext(ctx, scanID)
+}
+
+// GetScanResults fetches the findings of a scan. opts may be nil.
+func (c *ScansClient) GetScanResults(scanID string, opts *ScanResultsOptions) (*ScanResult, error) {
+	return c.GetScanResultsWithContext(context.Background(), scanID, opts)
+}
+
+// GetScanResultsWithContext fetches the findings of a scan. opts may be nil.
+func (c *ScansClient) GetScanResultsWithContext(ctx context.Context, scanID string, opts *ScanResultsOptions) (*ScanResult, error) {
+	if opts == nil {
+		opts = &ScanResultsOptions{}
+	}
+	return c.client.scanManagement.Get{scan_id:uuid}resultsWithContext(ctx, scanID, optionalString(string(opts.Severity)), optionalString(opts.RuleType), optionalInt(opts.Limit))
+}
+
+// CancelScan cancels a pending or running scan
+func (c *ScansClient) CancelScan(scanID string) (*ScanCancelResponse, error) {
+	return c.CancelScanWithContext(context.Background(), scanID)
+}
+
+// CancelScanWithContext cancels a pending or running scan
+func (c *ScansClient) CancelScanWithContext(ctx context.Context, scanID string) (*ScanCancelResponse, error) {
+	return c.client.scanManagement.Post{scan_id:uuid}cancelWithContext(ctx, scanID)
+}
+
+// filters returns the list filters as API parameters
+func (o *ScanListOptions) filters() (status *string, organizationID *string) {
+	return optionalString(string(o.Status)), optionalString(o.OrganizationID)
+}
+
+// optionalString returns nil for an empty value so the parameter is omitted
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// optionalInt returns nil for a zero value so the parameter is omitted
+func optionalInt(value int) *float64 {
+	if value == 0 {
+		return nil
+	}
+	converted := float64(value)
+	return &converted
+}
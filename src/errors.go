@@ -0,0 +1,153 @@
+package tavo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize caps how much of an error response body is kept on APIError
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned for any non-2xx response from the Tavo API
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+
+	// Human readable message, taken from the error response when available
+	Message string
+
+	// Decoded error payload; zero-valued when the body was not JSON
+	Response ErrorResponse
+
+	// Request ID reported by the server, if any
+	RequestID string
+
+	// Delay requested by the server through the Retry-After header
+	RetryAfter time.Duration
+
+	// HTTP method and URL of the failed request
+	Method string
+	URL    string
+
+	// Raw response body
+	Body []byte
+}
+
+// TavoError is an alias for APIError
+type TavoError = APIError
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("tavo: HTTP %d", e.StatusCode)
+	if e.Method != "" && e.URL != "" {
+		msg = fmt.Sprintf("tavo: %s %s: HTTP %d", e.Method, e.URL, e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a non-2xx response, consuming its body
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		apiErr.RetryAfter = delay
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.URL = resp.Request.URL.Redacted()
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	apiErr.Body = body
+
+	if len(body) > 0 && json.Unmarshal(body, &apiErr.Response) == nil {
+		switch {
+		case apiErr.Response.Message != "":
+			apiErr.Message = apiErr.Response.Message
+		case apiErr.Response.Error != "":
+			apiErr.Message = apiErr.Response.Error
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiErr
+}
+
+// AsAPIError unwraps err into an *APIError if it is one
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// hasStatus reports whether err is an APIError with one of the given status codes
+func hasStatus(err error, codes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBadRequest reports whether err is a 400 or 422 API error
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsUnauthorized reports whether err is a 401 API error
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 API error
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is a 404 API error
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 API error
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is a 429 API error
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError reports whether err is a 5xx API error
+func IsServerError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode >= 500
+}
@@ -0,0 +1,144 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantError   string
+	}{
+		{"message field", http.StatusBadRequest, `{"error": "bad_request", "message": "name is required"}`, "name is required", "bad_request"},
+		{"error field only", http.StatusNotFound, `{"error": "scan not found"}`, "scan not found", "scan not found"},
+		{"details", http.StatusUnprocessableEntity, `{"message": "invalid", "details": [{"field": "name"}]}`, "invalid", ""},
+		{"plain text", http.StatusBadGateway, "upstream unavailable\n", "upstream unavailable", ""},
+		{"unrelated json", http.StatusConflict, `{"detail": "exists"}`, `{"detail": "exists"}`, ""},
+		{"empty body", http.StatusServiceUnavailable, "", "Service Unavailable", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/api/v1/scans/s1?token=secret", nil)
+			req.URL.User = url.UserPassword("user", "password")
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{"X-Request-Id": {"req-1"}, "Retry-After": {"3"}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+				Request:    req,
+			}
+
+			apiErr := newAPIError(resp)
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.wantMessage || apiErr.Response.Error != tt.wantError {
+				t.Errorf("APIError = %+v, want status %d, message %q, error %q", apiErr, tt.status, tt.wantMessage, tt.wantError)
+			}
+			if apiErr.RequestID != "req-1" || apiErr.RetryAfter != 3*time.Second || string(apiErr.Body) != tt.body {
+				t.Errorf("APIError = %+v", apiErr)
+			}
+			if apiErr.Method != http.MethodGet || strings.Contains(apiErr.URL, "password") {
+				t.Errorf("Method, URL = %s, %s; want GET and a redacted URL", apiErr.Method, apiErr.URL)
+			}
+			want := fmt.Sprintf("tavo: GET %s: HTTP %d: %s (request id req-1)", apiErr.URL, tt.status, tt.wantMessage)
+			if apiErr.Error() != want {
+				t.Errorf("Error() = %q, want %q", apiErr.Error(), want)
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorLimitsBody(t *testing.T) {
+	body := strings.Repeat("x", maxErrorBodySize+100)
+	apiErr := newAPIError(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(body))})
+	if len(apiErr.Body) != maxErrorBodySize {
+		t.Errorf("len(Body) = %d, want %d", len(apiErr.Body), maxErrorBodySize)
+	}
+	if apiErr.Error() != "tavo: HTTP 500: "+strings.Repeat("x", maxErrorBodySize) {
+		t.Errorf("Error() without a request = %.40q", apiErr.Error())
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	helpers := map[string]func(error) bool{
+		"IsBadRequest":   IsBadRequest,
+		"IsUnauthorized": IsUnauthorized,
+		"IsForbidden":    IsForbidden,
+		"IsNotFound":     IsNotFound,
+		"IsConflict":     IsConflict,
+		"IsRateLimited":  IsRateLimited,
+		"IsServerError":  IsServerError,
+	}
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusBadRequest, "IsBadRequest"},
+		{http.StatusUnprocessableEntity, "IsBadRequest"},
+		{http.StatusUnauthorized, "IsUnauthorized"},
+		{http.StatusForbidden, "IsForbidden"},
+		{http.StatusNotFound, "IsNotFound"},
+		{http.StatusConflict, "IsConflict"},
+		{http.StatusTooManyRequests, "IsRateLimited"},
+		{http.StatusInternalServerError, "IsServerError"},
+		{http.StatusServiceUnavailable, "IsServerError"},
+		{http.StatusTeapot, ""},
+	}
+	for _, tt := range tests {
+		apiErr := &APIError{StatusCode: tt.status}
+		wrapped := []error{
+			apiErr,
+			fmt.Errorf("get scan: %w", apiErr),
+			errors.Join(errors.New("other"), fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", apiErr))),
+		}
+		for _, err := range wrapped {
+			for name, helper := range helpers {
+				if got := helper(err); got != (name == tt.want) {
+					t.Errorf("%s(%v) = %v", name, err, got)
+				}
+			}
+			if got, ok := AsAPIError(err); !ok || got != apiErr {
+				t.Errorf("AsAPIError(%v) = %v, %v", err, got, ok)
+			}
+		}
+	}
+
+	for name, helper := range helpers {
+		if helper(nil) || helper(errors.New("tavo: HTTP 404")) || helper(context.Canceled) {
+			t.Errorf("%s matched an error that is not an APIError", name)
+		}
+	}
+	if _, ok := AsAPIError(nil); ok {
+		t.Error("AsAPIError(nil) reported an APIError")
+	}
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "not_found", "message": "scan s1 not found"}`))
+	})
+
+	err := client.do(context.Background(), http.MethodGet, "/scans/s1", nil, nil, nil)
+	wrapped := fmt.Errorf("load scan: %w", err)
+	var apiErr *APIError
+	if !errors.As(wrapped, &apiErr) || !IsNotFound(wrapped) {
+		t.Fatalf("err = %v, want a wrapped 404 APIError", wrapped)
+	}
+	if apiErr.Message != "scan s1 not found" || apiErr.RequestID != "abc" || apiErr.Method != http.MethodGet || !strings.HasSuffix(apiErr.URL, "/scans/s1") {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	var tavoErr *TavoError
+	if !errors.As(wrapped, &tavoErr) || tavoErr != apiErr {
+		t.Error("errors.As does not find the error as a *TavoError")
+	}
+}
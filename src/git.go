@@ -0,0 +1,45 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// runGit runs git in dir and returns its trimmed standard output
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	output, err := gitOutput(ctx, dir, args...)
+	return strings.TrimSpace(string(output)), err
+}
+
+// gitOutput runs git in dir and returns its raw standard output
+func gitOutput(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), message)
+		}
+		return nil, fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// gitHead returns the current branch and commit of the repository containing
+// dir. Both are empty outside a git repository, and branch is empty on a
+// detached HEAD.
+func gitHead(ctx context.Context, dir string) (branch, commitSHA string) {
+	commitSHA, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", ""
+	}
+	branch, err = runGit(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		branch = ""
+	}
+	return branch, commitSHA
+}
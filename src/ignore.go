@@ -0,0 +1,173 @@
+package tavo
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreFileNames are the ignore files read from every directory of a walked tree
+var ignoreFileNames = []string{".gitignore", ".tavoignore"}
+
+// ignoreRule is one pattern from an ignore file, following .gitignore syntax
+type ignoreRule struct {
+	// Slash-separated directory of the ignore file, relative to the walk root
+	base string
+
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreRules holds the rules of every ignore file seen so far. Later rules
+// take precedence, so files in deeper directories override their parents.
+type ignoreRules []ignoreRule
+
+// load appends the rules of the named ignore files in dir, whose
+// slash-separated path relative to the walk root is base
+func (r *ignoreRules) load(dir, base string, names []string) error {
+	for _, name := range names {
+		file, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = r.parse(file, base)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parse appends the rules read from an ignore file in the directory base
+func (r *ignoreRules) parse(reader io.Reader, base string) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text(), base); ok {
+			*r = append(*r, rule)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseIgnoreRule parses one line of an ignore file
+func parseIgnoreRule(line, base string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	switch {
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	rule.anchored = strings.Contains(line, "/")
+	rule.pattern = strings.TrimPrefix(line, "/")
+	return rule, rule.pattern != ""
+}
+
+// ignored reports whether the slash-separated path rel, relative to the walk
+// root, is excluded by the rules
+func (r ignoreRules) ignored(rel string, isDir bool) bool {
+	excluded := false
+	for _, rule := range r {
+		if rule.matches(rel, isDir) {
+			excluded = !rule.negate
+		}
+	}
+	return excluded
+}
+
+// matches reports whether the rule applies to rel
+func (rule ignoreRule) matches(rel string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+	if rule.base != "" {
+		if !strings.HasPrefix(rel, rule.base+"/") {
+			return false
+		}
+		rel = rel[len(rule.base)+1:]
+	}
+	if !rule.anchored {
+		return matchGlob(rule.pattern, path.Base(rel))
+	}
+	return matchGlob(rule.pattern, rel)
+}
+
+// ignoredPath reports whether the slash-separated file path rel or one of
+// its parent directories is excluded, for checking files without a tree walk
+func (r ignoreRules) ignoredPath(rel string) bool {
+	for i := 0; i < len(rel); i++ {
+		if rel[i] == '/' && r.ignored(rel[:i], true) {
+			return true
+		}
+	}
+	return r.ignored(rel, false)
+}
+
+// matchGlob matches a slash-separated path against a glob pattern in which
+// "**" matches any number of directories
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchAnyGlob reports whether rel matches one of patterns. Patterns without a
+// slash are matched against the base name, like ignore rules.
+func matchAnyGlob(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(pattern, "/")
+		if !strings.Contains(pattern, "/") {
+			if matchGlob(pattern, path.Base(rel)) {
+				return true
+			}
+			continue
+		}
+		if matchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
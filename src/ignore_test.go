@@ -0,0 +1,106 @@
+package tavo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIgnoreRule(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   ignoreRule
+		wantOK bool
+	}{
+		{"", ignoreRule{}, false},
+		{"   ", ignoreRule{}, false},
+		{"# comment", ignoreRule{}, false},
+		{"*.log", ignoreRule{pattern: "*.log"}, true},
+		{"*.log \t\r", ignoreRule{pattern: "*.log"}, true},
+		{"!keep.log", ignoreRule{pattern: "keep.log", negate: true}, true},
+		{`\!bang`, ignoreRule{pattern: "!bang"}, true},
+		{`\#hash`, ignoreRule{pattern: "#hash"}, true},
+		{"build/", ignoreRule{pattern: "build", dirOnly: true}, true},
+		{"/root.txt", ignoreRule{pattern: "root.txt", anchored: true}, true},
+		{"docs/*.md", ignoreRule{pattern: "docs/*.md", anchored: true}, true},
+		{"**/tmp/", ignoreRule{pattern: "**/tmp", dirOnly: true, anchored: true}, true},
+		{"/", ignoreRule{}, false},
+		{"!", ignoreRule{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseIgnoreRule(tt.line, "")
+		if ok != tt.wantOK || ok && got != tt.want {
+			t.Errorf("parseIgnoreRule(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestIgnoredPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string // ignore file content by directory
+		path  string
+		want  bool
+	}{
+		{"unanchored name", map[string]string{"": "*.log"}, "deep/dir/debug.log", true},
+		{"no match", map[string]string{"": "*.log"}, "main.go", false},
+		{"negation", map[string]string{"": "*.log\n!keep.log"}, "logs/keep.log", false},
+		{"negation order", map[string]string{"": "!keep.log\n*.log"}, "keep.log", true},
+		{"anchored at the root", map[string]string{"": "/root.txt"}, "root.txt", true},
+		{"anchored not below the root", map[string]string{"": "/root.txt"}, "sub/root.txt", false},
+		{"pattern with a slash is anchored", map[string]string{"": "docs/*.md"}, "docs/guide.md", true},
+		{"pattern with a slash elsewhere", map[string]string{"": "docs/*.md"}, "src/docs/guide.md", false},
+		{"single star stays in its segment", map[string]string{"": "docs/*.md"}, "docs/api/guide.md", false},
+		{"trailing slash ignores the directory", map[string]string{"": "build/"}, "build/out/app.js", true},
+		{"trailing slash does not match a file", map[string]string{"": "build/"}, "build", false},
+		{"nested directory", map[string]string{"": "build/"}, "web/build/app.js", true},
+		{"leading double star", map[string]string{"": "**/fixtures/*.json"}, "a/b/fixtures/x.json", true},
+		{"leading double star at the root", map[string]string{"": "**/fixtures/*.json"}, "fixtures/x.json", true},
+		{"middle double star", map[string]string{"": "src/**/gen.go"}, "src/a/b/gen.go", true},
+		{"middle double star matching nothing", map[string]string{"": "src/**/gen.go"}, "src/gen.go", true},
+		{"trailing double star", map[string]string{"": "vendor/**"}, "vendor/pkg/mod.go", true},
+		{"excluded parent cannot be re-included", map[string]string{"": "build/\n!build/keep.txt"}, "build/keep.txt", true},
+		{"nested ignore file", map[string]string{"sub": "*.tmp"}, "sub/x/a.tmp", true},
+		{"nested ignore file outside its directory", map[string]string{"sub": "*.tmp"}, "a.tmp", false},
+		{"nested anchored", map[string]string{"sub": "/gen"}, "sub/gen", true},
+		{"nested anchored deeper", map[string]string{"sub": "/gen"}, "sub/x/gen", false},
+		{"nested negation overrides the parent", map[string]string{"": "*.tmp", "sub": "!keep.tmp"}, "sub/keep.tmp", false},
+		{"comments and blank lines", map[string]string{"": "# *.go\n\n"}, "main.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Parent directories are loaded first, as in a walk
+			var rules ignoreRules
+			for _, base := range []string{"", "sub"} {
+				if content, ok := tt.files[base]; ok {
+					if err := rules.parse(strings.NewReader(content), base); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if got := rules.ignoredPath(tt.path); got != tt.want {
+				t.Errorf("ignoredPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchAnyGlob(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{[]string{"*.go"}, "cmd/tool/main.go", true},
+		{[]string{"**/*.go"}, "main.go", true},
+		{[]string{"/cmd/**"}, "cmd/tool/main.go", true},
+		{[]string{"cmd/*.go"}, "cmd/tool/main.go", false},
+		{[]string{"*.py", "*.js"}, "web/app.js", true},
+		{[]string{"[", "*.go"}, "main.go", true},
+		{nil, "main.go", false},
+	}
+	for _, tt := range tests {
+		if got := matchAnyGlob(tt.patterns, tt.path); got != tt.want {
+			t.Errorf("matchAnyGlob(%q, %q) = %v, want %v", tt.patterns, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrJobFailed is reported for a tracked job that ended in the failed state
+	ErrJobFailed = errors.New("tavo: job failed")
+
+	// ErrJobCancelled is reported for a tracked job that was cancelled
+	ErrJobCancelled = errors.New("tavo: job was cancelled")
+)
+
+// JobTransition describes a tracked job moving from one state to another.
+// From is empty the first time a job's state is observed.
+type JobTransition struct {
+	JobID  string
+	From   JobState
+	To     JobState
+	Status *JobStatus
+}
+
+// JobResult is the final state of one tracked job
+type JobResult struct {
+	JobID string
+
+	// Last known status; nil if the job was never fetched
+	Status *JobStatus
+
+	// Why the job did not complete: ErrJobFailed, ErrJobCancelled, an API
+	// error or the context error that stopped Wait
+	Err error
+}
+
+// JobTrackerOptions configures a JobTracker
+type JobTrackerOptions struct {
+	// Poll interval bounds. Each job's interval starts at MinInterval, grows by
+	// Multiplier while the job makes no progress and resets when it does.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+
+	// Listen on the live event stream and poll only as a fallback. If the
+	// stream cannot be opened, the tracker silently falls back to polling.
+	UseEvents bool
+
+	// OnTransition is called whenever a job changes state
+	OnTransition func(transition JobTransition)
+
+	// OnProgress is called with the aggregate progress, 0-100, whenever a job's
+	// progress or state changes
+	OnProgress func(progress float64)
+}
+
+// DefaultJobTrackerOptions returns the options used when NewJobTracker is given nil
+func DefaultJobTrackerOptions() *JobTrackerOptions {
+	return &JobTrackerOptions{
+		MinInterval: time.Second,
+		MaxInterval: 30 * time.Second,
+		Multiplier:  1.5,
+	}
+}
+
+// JobTracker watches many background jobs concurrently, such as the jobs
+// started by bulk operations, repository syncs and plugin executions
+type JobTracker struct {
+	client  *Client
+	options JobTrackerOptions
+
+	mu     sync.Mutex
+	jobs   map[string]*trackedJob
+	order  []string
+	ctx    context.Context
+	stream *EventStream
+
+	notifyMu sync.Mutex
+}
+
+// trackedJob is the state of one job inside a JobTracker
+type trackedJob struct {
+	id      string
+	status  *JobStatus
+	err     error
+	final   bool
+	running bool
+	done    chan struct{}
+	wake    chan struct{}
+}
+
+// NewJobTracker creates a tracker that polls jobs through client
+func NewJobTracker(client *Client, opts *JobTrackerOptions) *JobTracker {
+	options := DefaultJobTrackerOptions()
+	if opts != nil {
+		copied := *opts
+		options = &copied
+	}
+	defaults := DefaultJobTrackerOptions()
+	if options.MinInterval <= 0 {
+		options.MinInterval = defaults.MinInterval
+	}
+	if options.MaxInterval < options.MinInterval {
+		options.MaxInterval = max(defaults.MaxInterval, options.MinInterval)
+	}
+	if options.Multiplier < 1 {
+		options.Multiplier = defaults.Multiplier
+	}
+	return &JobTracker{client: client, options: *options, jobs: make(map[string]*trackedJob)}
+}
+
+// Add starts tracking jobIDs. Jobs added while Wait is running are picked up
+// by that call. Adding a job that is already tracked has no effect.
+func (t *JobTracker) Add(jobIDs ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range jobIDs {
+		if _, ok := t.jobs[id]; ok || id == "" {
+			continue
+		}
+		job := &trackedJob{id: id, wake: make(chan struct{}, 1)}
+		t.jobs[id] = job
+		t.order = append(t.order, id)
+		if t.stream != nil {
+			_ = t.stream.SubscribeJob(id)
+		}
+		if t.ctx != nil {
+			t.startLocked(job)
+		}
+	}
+}
+
+// Progress returns the average progress of all tracked jobs, counting
+// finished jobs as 100
+func (t *JobTracker) Progress() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progressLocked()
+}
+
+// Wait polls every tracked job until all of them reach a terminal state or
+// ctx is done. Results are returned in the order jobs were added; the error
+// joins the errors of every job that did not complete.
+func (t *JobTracker) Wait(ctx context.Context) ([]JobResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if t.options.UseEvents {
+		if stream, err := t.client.websockets.Connect(ctx, nil); err == nil {
+			t.mu.Lock()
+			t.stream = stream
+			for _, id := range t.order {
+				_ = stream.SubscribeJob(id)
+			}
+			t.mu.Unlock()
+
+			listening := make(chan struct{})
+			go func() {
+				defer close(listening)
+				t.listen(stream)
+			}()
+			// Closing the stream ends listen; wait for it so no goroutine outlives Wait
+			defer func() {
+				stream.Close()
+				<-listening
+			}()
+		}
+	}
+
+	t.mu.Lock()
+	t.ctx = ctx
+	for _, id := range t.order {
+		t.startLocked(t.jobs[id])
+	}
+	t.mu.Unlock()
+
+	for {
+		t.mu.Lock()
+		var running *trackedJob
+		for _, id := range t.order {
+			if job := t.jobs[id]; job.running {
+				running = job
+				break
+			}
+		}
+		if running == nil {
+			t.ctx = nil
+			t.stream = nil
+			t.mu.Unlock()
+			break
+		}
+		t.mu.Unlock()
+		<-running.done
+	}
+
+	return t.results()
+}
+
+// results collects the final state of every job
+func (t *JobTracker) results() ([]JobResult, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	results := make([]JobResult, 0, len(t.order))
+	var errs []error
+	for _, id := range t.order {
+		job := t.jobs[id]
+		results = append(results, JobResult{JobID: id, Status: job.status, Err: job.err})
+		if job.err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", id, job.err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// startLocked starts polling a job unless it is finished or already polled
+func (t *JobTracker) startLocked(job *trackedJob) {
+	if job.final || job.running {
+		return
+	}
+	job.running = true
+	job.err = nil
+	job.done = make(chan struct{})
+	go t.poll(t.ctx, job)
+}
+
+// poll fetches a job's status with adaptive backoff until it is terminal
+func (t *JobTracker) poll(ctx context.Context, job *trackedJob) {
+	defer func() {
+		t.mu.Lock()
+		job.running = false
+		close(job.done)
+		t.mu.Unlock()
+	}()
+
+	interval := t.options.MinInterval
+	for {
+		raw, err := t.client.jobs.Getstatus{job_id}WithContext(ctx, job.id)
+		if err == nil {
+			var status JobStatus
+			if err = decodeResult(raw, &status); err == nil {
+				if status.JobID == "" {
+					status.JobID = job.id
+				}
+				if t.update(&status) {
+					interval = t.options.MinInterval
+				} else {
+					interval = min(time.Duration(float64(interval)*t.options.Multiplier), t.options.MaxInterval)
+				}
+				if status.Status.IsTerminal() {
+					t.finish(job, jobError(&status))
+					return
+				}
+			}
+		}
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			t.stop(job, ctx.Err())
+			return
+		case IsRateLimited(err) || IsServerError(err):
+			// Keep polling through transient failures that outlived the client's retries
+			if apiErr, ok := AsAPIError(err); ok && apiErr.RetryAfter > interval {
+				interval = apiErr.RetryAfter
+			}
+		default:
+			t.finish(job, err)
+			return
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			t.stop(job, ctx.Err())
+			return
+		case <-job.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// listen applies job events from stream and wakes the pollers of jobs that finished
+func (t *JobTracker) listen(stream *EventStream) {
+	for event := range stream.Events() {
+		payload, ok := event.Payload.(*JobStatusEvent)
+		if !ok {
+			continue
+		}
+		t.mu.Lock()
+		job, tracked := t.jobs[payload.JobID]
+		t.mu.Unlock()
+		if !tracked {
+			continue
+		}
+
+		status := &JobStatus{
+			JobID:    payload.JobID,
+			JobType:  payload.JobType,
+			Status:   parseJobState(payload.Status),
+			Progress: payload.Progress,
+			Message:  payload.Message,
+			Error:    payload.Error,
+		}
+		t.update(status)
+		if status.Status.IsTerminal() {
+			// Let the poller fetch the final status, including the job's result
+			select {
+			case job.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// update records a job's status, notifies the callbacks and reports whether
+// the state or progress changed
+func (t *JobTracker) update(status *JobStatus) bool {
+	// Holding notifyMu across the update keeps the callbacks in the order the changes were made
+	t.notifyMu.Lock()
+	defer t.notifyMu.Unlock()
+
+	t.mu.Lock()
+	job := t.jobs[status.JobID]
+	if job == nil {
+		t.mu.Unlock()
+		return false
+	}
+	previous := job.status
+	job.status = status
+	var from JobState
+	if previous != nil {
+		from = previous.Status
+	}
+	changed := previous == nil || from != status.Status || previous.Progress != status.Progress
+	aggregate := t.progressLocked()
+	t.mu.Unlock()
+
+	if !changed {
+		return false
+	}
+	if (previous == nil || from != status.Status) && t.options.OnTransition != nil {
+		t.options.OnTransition(JobTransition{JobID: status.JobID, From: from, To: status.Status, Status: status})
+	}
+	if t.options.OnProgress != nil {
+		t.options.OnProgress(aggregate)
+	}
+	return true
+}
+
+// finish marks a job as done for good with err as its outcome
+func (t *JobTracker) finish(job *trackedJob, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job.final = true
+	job.err = err
+}
+
+// stop records why polling a job stopped early; the job is polled again by the next Wait
+func (t *JobTracker) stop(job *trackedJob, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job.err = err
+}
+
+// progressLocked averages the progress of all jobs
+func (t *JobTracker) progressLocked() float64 {
+	if len(t.order) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, id := range t.order {
+		status := t.jobs[id].status
+		switch {
+		case status == nil:
+		case status.Status.IsTerminal():
+			total += 100
+		default:
+			total += min(max(status.Progress, 0), 100)
+		}
+	}
+	return total / float64(len(t.order))
+}
+
+// jobError returns the error reported for a job in a terminal state
+func jobError(status *JobStatus) error {
+	switch status.Status {
+	case JobStateFailed:
+		if status.Error != "" {
+			return fmt.Errorf("%w: %s", ErrJobFailed, status.Error)
+		}
+		return ErrJobFailed
+	case JobStateCancelled:
+		return ErrJobCancelled
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// jobServer answers status requests for each job with its states in order,
+// repeating the last one. A state of "HTTP <code>" fails the request.
+type jobServer struct {
+	mu     sync.Mutex
+	states map[string][]string
+	polls  map[string]int
+}
+
+func newJobServer(states map[string][]string) *jobServer {
+	return &jobServer{states: states, polls: make(map[string]int)}
+}
+
+func (s *jobServer) serve(w http.ResponseWriter, r *http.Request) {
+	id := path.Base(r.URL.Path)
+	s.mu.Lock()
+	s.polls[id]++
+	states := s.states[id]
+	var state string
+	if len(states) > 0 {
+		state = states[min(s.polls[id], len(states))-1]
+	}
+	s.mu.Unlock()
+
+	if state == "" {
+		state = "HTTP 404"
+	}
+	if status, ok := strings.CutPrefix(state, "HTTP "); ok {
+		var code int
+		fmt.Sscan(status, &code)
+		w.WriteHeader(code)
+		w.Write([]byte(`{"error": "job unavailable"}`))
+		return
+	}
+	fmt.Fprintf(w, `{"job_id": %q, %s}`, id, state)
+}
+
+// setStates replaces the states served for a job and restarts its sequence
+func (s *jobServer) setStates(id string, states ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[id] = states
+	s.polls[id] = 0
+}
+
+// pollCount returns how often a job's status was requested
+func (s *jobServer) pollCount(id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polls[id]
+}
+
+// fastTracker polls every millisecond
+func fastTracker() *JobTrackerOptions {
+	return &JobTrackerOptions{MinInterval: time.Millisecond, MaxInterval: time.Millisecond}
+}
+
+// trackerGoroutines returns the stacks of goroutines still running tracker or event stream code
+func trackerGoroutines() []string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	var found []string
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, "(*JobTracker)") || strings.Contains(stack, "(*EventStream)") {
+			found = append(found, stack)
+		}
+	}
+	return found
+}
+
+// checkNoGoroutines fails the test if tracker goroutines outlive Wait
+func checkNoGoroutines(t *testing.T) {
+	t.Helper()
+	// A poller may still be returning from its deferred cleanup
+	deadline := time.Now().Add(time.Second)
+	for {
+		left := trackerGoroutines()
+		if len(left) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines left after Wait:\n%s", len(left), strings.Join(left, "\n\n"))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestJobTrackerTransitions(t *testing.T) {
+	server := newJobServer(map[string][]string{
+		"job-1": {`"status": "pending"`, `"status": "running", "progress": 50`, `"status": "completed", "result": {"synced": 3}`},
+		"job-2": {`"state": "queued"`, `"state": "in_progress", "progress": 20`, `"state": "in_progress", "progress": 80`, `"state": "done"`},
+	})
+	client := newTestClient(t, server.serve)
+
+	var transitions []string
+	opts := fastTracker()
+	opts.OnTransition = func(tr JobTransition) {
+		transitions = append(transitions, fmt.Sprintf("%s:%s->%s", tr.JobID, tr.From, tr.To))
+	}
+	tracker := NewJobTracker(client, opts)
+	tracker.Add("job-1", "job-2", "job-1", "")
+
+	results, err := tracker.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	checkNoGoroutines(t)
+
+	if len(results) != 2 || results[0].JobID != "job-1" || results[1].JobID != "job-2" {
+		t.Fatalf("results = %+v, want job-1 and job-2 in the order added", results)
+	}
+	for _, result := range results {
+		if result.Err != nil || result.Status == nil || result.Status.Status != JobStateCompleted {
+			t.Errorf("result = %+v, want a completed job", result)
+		}
+	}
+	if result, ok := results[0].Status.Result.(map[string]interface{}); !ok || result["synced"] != float64(3) {
+		t.Errorf("job-1 result = %v, want the result of the final fetch", results[0].Status.Result)
+	}
+
+	// Transitions of different jobs interleave, but each job's are in order
+	perJob := map[string][]string{}
+	for _, tr := range transitions {
+		id, _, _ := strings.Cut(tr, ":")
+		perJob[id] = append(perJob[id], tr)
+	}
+	want := map[string][]string{
+		"job-1": {"job-1:->pending", "job-1:pending->running", "job-1:running->completed"},
+		"job-2": {"job-2:->queued", "job-2:queued->running", "job-2:running->completed"},
+	}
+	for id, wantTransitions := range want {
+		if strings.Join(perJob[id], " ") != strings.Join(wantTransitions, " ") {
+			t.Errorf("%s transitions = %q, want %q", id, perJob[id], wantTransitions)
+		}
+	}
+	if progress := tracker.Progress(); progress != 100 {
+		t.Errorf("Progress = %v, want 100", progress)
+	}
+}
+
+func TestJobTrackerTerminalFailures(t *testing.T) {
+	server := newJobServer(map[string][]string{
+		"failed":    {`"status": "running"`, `"status": "failed", "error": "disk full"`},
+		"errored":   {`"status": "error"`},
+		"cancelled": {`"status": "canceled"`},
+		"forbidden": {"HTTP 403"},
+		"flaky":     {"HTTP 503", `"status": "completed"`},
+		"completed": {`"status": "completed"`},
+	})
+	client := newTestClient(t, server.serve, WithRetryPolicy(NoRetries()))
+	tracker := NewJobTracker(client, fastTracker())
+	tracker.Add("failed", "errored", "cancelled", "forbidden", "flaky", "completed")
+
+	results, err := tracker.Wait(context.Background())
+	checkNoGoroutines(t)
+	if !errors.Is(err, ErrJobFailed) || !errors.Is(err, ErrJobCancelled) || !IsForbidden(err) {
+		t.Errorf("err = %v, want the failed, cancelled and forbidden jobs joined", err)
+	}
+	if err != nil && (!strings.Contains(err.Error(), "job failed: tavo: job failed: disk full") || strings.Contains(err.Error(), "job completed")) {
+		t.Errorf("err = %v, want one message per job that did not complete", err)
+	}
+
+	tests := []struct {
+		id    string
+		check func(error) bool
+	}{
+		{"failed", func(err error) bool { return errors.Is(err, ErrJobFailed) }},
+		{"errored", func(err error) bool { return err == ErrJobFailed }},
+		{"cancelled", func(err error) bool { return err == ErrJobCancelled }},
+		{"forbidden", IsForbidden},
+		{"flaky", func(err error) bool { return err == nil }},
+		{"completed", func(err error) bool { return err == nil }},
+	}
+	for i, tt := range tests {
+		if results[i].JobID != tt.id || !tt.check(results[i].Err) {
+			t.Errorf("results[%d] = %+v, unexpected for %s", i, results[i], tt.id)
+		}
+	}
+	if results[3].Status != nil {
+		t.Errorf("forbidden job status = %+v, want nil", results[3].Status)
+	}
+
+	// Finished jobs are not polled again
+	polls := server.pollCount("failed")
+	if _, err := tracker.Wait(context.Background()); !errors.Is(err, ErrJobFailed) || server.pollCount("failed") != polls {
+		t.Errorf("second Wait = %v after %d more polls", err, server.pollCount("failed")-polls)
+	}
+}
+
+func TestJobTrackerAggregateProgress(t *testing.T) {
+	server := newJobServer(map[string][]string{
+		"half":      {`"status": "running", "progress": 50`},
+		"overshoot": {`"status": "running", "progress": 150`},
+		"negative":  {`"status": "running", "progress": -10`},
+		"failed":    {`"status": "failed"`},
+	})
+	client := newTestClient(t, server.serve)
+
+	// Every job is fetched once; the wait is then stopped during the minute-long sleep
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var reported []float64
+	tracker := NewJobTracker(client, &JobTrackerOptions{
+		MinInterval: time.Minute,
+		OnProgress: func(progress float64) {
+			if reported = append(reported, progress); len(reported) == 4 {
+				cancel()
+			}
+		},
+	})
+	if progress := tracker.Progress(); progress != 0 {
+		t.Errorf("Progress without jobs = %v, want 0", progress)
+	}
+	tracker.Add("half", "overshoot", "negative", "failed")
+
+	if _, err := tracker.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait = %v, want context.Canceled", err)
+	}
+	checkNoGoroutines(t)
+
+	// 50, clamped 100, clamped 0 and 100 for the finished job
+	if progress := tracker.Progress(); progress != 62.5 {
+		t.Errorf("Progress = %v, want 62.5", progress)
+	}
+	if len(reported) != 4 || reported[3] != 62.5 {
+		t.Errorf("reported progress = %v, want four reports ending at 62.5", reported)
+	}
+	for i := 1; i < len(reported); i++ {
+		if reported[i] < reported[i-1] {
+			t.Errorf("reported progress = %v, want it to only grow", reported)
+		}
+	}
+}
+
+func TestJobTrackerContextCancellation(t *testing.T) {
+	server := newJobServer(map[string][]string{
+		"done":    {`"status": "completed"`},
+		"running": {`"status": "running", "progress": 30`},
+	})
+	client := newTestClient(t, server.serve)
+
+	// Cancel once one job has finished and the other is running at 30%
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := fastTracker()
+	opts.OnProgress = func(progress float64) {
+		if progress == 65 {
+			cancel()
+		}
+	}
+	tracker := NewJobTracker(client, opts)
+	tracker.Add("done", "running")
+
+	results, err := tracker.Wait(ctx)
+	checkNoGoroutines(t)
+	if !errors.Is(err, context.Canceled) || results[0].Err != nil || !errors.Is(results[1].Err, context.Canceled) {
+		t.Fatalf("Wait = %+v, %v; want only the running job stopped by the cancellation", results, err)
+	}
+	if results[1].Status == nil || results[1].Status.Progress != 30 {
+		t.Errorf("running job status = %+v, want the last fetched status", results[1].Status)
+	}
+
+	// The stopped job is polled again by the next Wait, the finished one is not
+	server.setStates("running", `"status": "completed"`)
+	donePolls := server.pollCount("done")
+	results, err = tracker.Wait(context.Background())
+	checkNoGoroutines(t)
+	if err != nil || results[1].Err != nil || results[1].Status.Status != JobStateCompleted {
+		t.Errorf("second Wait = %+v, %v; want every job completed", results, err)
+	}
+	if server.pollCount("done") != donePolls {
+		t.Error("second Wait polled a finished job")
+	}
+}
+
+func TestJobTrackerAddDuringWait(t *testing.T) {
+	server := newJobServer(map[string][]string{
+		"first":  {`"status": "running"`, `"status": "running"`, `"status": "completed"`},
+		"second": {`"status": "completed"`},
+	})
+	client := newTestClient(t, server.serve)
+
+	var tracker *JobTracker
+	opts := fastTracker()
+	var once sync.Once
+	opts.OnTransition = func(tr JobTransition) {
+		once.Do(func() { tracker.Add("second") })
+	}
+	tracker = NewJobTracker(client, opts)
+	tracker.Add("first")
+
+	results, err := tracker.Wait(context.Background())
+	checkNoGoroutines(t)
+	if err != nil || len(results) != 2 || results[1].JobID != "second" || results[1].Status == nil {
+		t.Errorf("Wait = %+v, %v; want the job added while waiting tracked too", results, err)
+	}
+}
+
+func TestJobTrackerEvents(t *testing.T) {
+	jobs := newJobServer(map[string][]string{
+		"job-1": {`"status": "running", "progress": 10`, `"status": "completed", "progress": 100`},
+	})
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ws" {
+			jobs.serve(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var sub subscriptionMessage
+		if conn.ReadJSON(&sub) != nil {
+			return
+		}
+		for _, event := range []string{
+			`{"type": "job.status", "channel": "job:other", "data": {"job_id": "other", "status": "completed"}}`,
+			`{"type": "job.status", "channel": "job:job-1", "data": {"job_id": "job-1", "status": "processing", "progress": 60}}`,
+			`{"type": "job.status", "channel": "job:job-1", "data": {"job_id": "job-1", "status": "finished", "progress": 100}}`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		waitClosed(conn)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("test-key", "", server.URL, WithRetryPolicy(fastRetries()))
+
+	var mu sync.Mutex
+	var reported []float64
+	tracker := NewJobTracker(client, &JobTrackerOptions{
+		MinInterval: time.Minute,
+		UseEvents:   true,
+		OnProgress: func(progress float64) {
+			mu.Lock()
+			reported = append(reported, progress)
+			mu.Unlock()
+		},
+	})
+	tracker.Add("job-1")
+
+	// The completion event wakes the poller from its minute-long sleep
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results, err := tracker.Wait(ctx)
+	if err != nil || results[0].Status == nil || results[0].Status.Status != JobStateCompleted {
+		t.Fatalf("Wait = %+v, %v", results, err)
+	}
+	checkNoGoroutines(t)
+	if polls := jobs.pollCount("job-1"); polls != 2 {
+		t.Errorf("polled %d times, want 2", polls)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) == 0 || reported[len(reported)-1] != 100 {
+		t.Errorf("reported progress = %v, want it to end at 100", reported)
+	}
+}
@@ -0,0 +1,73 @@
+package tavo
+
+import (
+	"path"
+	"strings"
+)
+
+// languageByExtension maps lower-case file extensions to language names
+var languageByExtension = map[string]string{
+	".go":    "go",
+	".py":    "python",
+	".pyi":   "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".cjs":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".java":  "java",
+	".kt":    "kotlin",
+	".kts":   "kotlin",
+	".scala": "scala",
+	".rb":    "ruby",
+	".php":   "php",
+	".cs":    "csharp",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "cpp",
+	".cpp":   "cpp",
+	".cxx":   "cpp",
+	".hpp":   "cpp",
+	".rs":    "rust",
+	".swift": "swift",
+	".m":     "objective-c",
+	".sh":    "shell",
+	".bash":  "shell",
+	".zsh":   "shell",
+	".ps1":   "powershell",
+	".sql":   "sql",
+	".tf":    "terraform",
+	".hcl":   "hcl",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+	".json":  "json",
+	".xml":   "xml",
+	".html":  "html",
+	".htm":   "html",
+	".vue":   "vue",
+	".dart":  "dart",
+	".lua":   "lua",
+	".sol":   "solidity",
+}
+
+// languageByName maps well-known file names without a useful extension
+var languageByName = map[string]string{
+	"dockerfile":  "dockerfile",
+	"makefile":    "makefile",
+	"gemfile":     "ruby",
+	"rakefile":    "ruby",
+	"jenkinsfile": "groovy",
+}
+
+// DetectLanguage returns the language of a file from its name, or "" if it is not recognized
+func DetectLanguage(name string) string {
+	base := strings.ToLower(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if language, ok := languageByName[base]; ok {
+		return language
+	}
+	if strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile") {
+		return "dockerfile"
+	}
+	return languageByExtension[path.Ext(base)]
+}
@@ -0,0 +1,213 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// DefaultPageSize is the number of items requested per page when none is set
+const DefaultPageSize = 50
+
+// PageRequest describes the page a Pager wants next. Endpoints use whichever of
+// the fields matches their pagination scheme (skip/limit, page/per_page or limit/offset).
+type PageRequest struct {
+	// 1-based page number
+	Page int
+
+	// Number of items to skip, equal to (Page-1)*Size
+	Offset int
+
+	// Number of items per page
+	Size int
+}
+
+// PageFetchFunc fetches a single page. The result may be a []T, a
+// PaginatedResponse, a ListResponse or any JSON object holding one list of items.
+type PageFetchFunc func(ctx context.Context, page PageRequest) (interface{}, error)
+
+// PagerOption configures a Pager
+type PagerOption func(*pagerConfig)
+
+// pagerConfig holds the options shared by all pagers
+type pagerConfig struct {
+	pageSize int
+	maxItems int
+	paged    bool
+}
+
+// WithPageSize sets how many items are requested per page
+func WithPageSize(size int) PagerOption {
+	return func(c *pagerConfig) {
+		if size > 0 {
+			c.pageSize = size
+		}
+	}
+}
+
+// WithMaxItems stops iteration after n items. Zero means no limit.
+func WithMaxItems(n int) PagerOption {
+	return func(c *pagerConfig) {
+		c.maxItems = n
+	}
+}
+
+// withoutPaging marks an endpoint that returns all items in one response
+func withoutPaging() PagerOption {
+	return func(c *pagerConfig) {
+		c.paged = false
+	}
+}
+
+// Pager walks every page of a list endpoint
+type Pager[T any] struct {
+	fetch  PageFetchFunc
+	config pagerConfig
+}
+
+// NewPager creates a Pager that calls fetch for each page
+func NewPager[T any](fetch PageFetchFunc, opts ...PagerOption) *Pager[T] {
+	config := pagerConfig{pageSize: DefaultPageSize, paged: true}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return &Pager[T]{fetch: fetch, config: config}
+}
+
+// All returns an iterator over every item across all pages. Iteration stops at
+// the first error, which is yielded with the zero value of T.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := 0
+		request := PageRequest{Page: 1, Size: p.config.pageSize}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			raw, err := p.fetch(ctx, request)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			items, info, total, err := decodePage[T](raw)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				seen++
+				if p.config.maxItems > 0 && seen >= p.config.maxItems {
+					return
+				}
+			}
+
+			if !p.config.paged || len(items) == 0 {
+				return
+			}
+			switch {
+			case info != nil:
+				if !info.HasNext || (info.Pages > 0 && request.Page >= info.Pages) {
+					return
+				}
+			case total >= 0:
+				if request.Offset+len(items) >= total {
+					return
+				}
+			case len(items) < request.Size:
+				return
+			}
+
+			request.Page++
+			request.Offset += len(items)
+		}
+	}
+}
+
+// Collect gathers every item into a slice
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// listKeys are the object keys checked, in order, for a page's items
+var listKeys = []string{"data", "items", "results"}
+
+// decodePage extracts the items of one page along with any pagination metadata.
+// total is -1 when the response does not report a total item count.
+func decodePage[T any](raw interface{}) (items []T, info *PaginationInfo, total int, err error) {
+	total = -1
+	if typed, ok := raw.([]T); ok {
+		return typed, nil, total, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, total, err
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil, total, nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &items)
+		return items, nil, total, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, total, fmt.Errorf("decode page: %w", err)
+	}
+
+	if value, ok := fields["pagination"]; ok {
+		info = &PaginationInfo{}
+		if err := json.Unmarshal(value, info); err != nil {
+			return nil, nil, total, fmt.Errorf("decode pagination: %w", err)
+		}
+	}
+	if value, ok := fields["total"]; ok {
+		_ = json.Unmarshal(value, &total)
+	}
+
+	listField := ""
+	for _, key := range listKeys {
+		if _, ok := fields[key]; ok {
+			listField = key
+			break
+		}
+	}
+	if listField == "" {
+		for key, value := range fields {
+			value = bytes.TrimSpace(value)
+			if len(value) > 0 && value[0] == '[' {
+				if listField != "" {
+					return nil, nil, total, fmt.Errorf("decode page: ambiguous item lists %q and %q", listField, key)
+				}
+				listField = key
+			}
+		}
+	}
+	if listField == "" {
+		return nil, info, total, nil
+	}
+
+	if err := json.Unmarshal(fields[listField], &items); err != nil {
+		return nil, nil, total, fmt.Errorf("decode page items: %w", err)
+	}
+	return items, info, total, nil
+}
@@ -0,0 +1,290 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// pageFetcher serves pages from a function of the request and records the requests
+type pageFetcher struct {
+	requests []PageRequest
+	page     func(page PageRequest) (interface{}, error)
+}
+
+func (f *pageFetcher) fetch(ctx context.Context, page PageRequest) (interface{}, error) {
+	f.requests = append(f.requests, page)
+	return f.page(page)
+}
+
+// jsonPage decodes body the way an endpoint method returns an untyped response
+func jsonPage(body string) interface{} {
+	var page interface{}
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		panic(err)
+	}
+	return page
+}
+
+// numbers returns the items from offset to min(offset+size, total)
+func numbers(page PageRequest, total int) []int {
+	var items []int
+	for i := page.Offset; i < total && i < page.Offset+page.Size; i++ {
+		items = append(items, i)
+	}
+	return items
+}
+
+func TestPagerStopConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []PagerOption
+		page      func(page PageRequest) (interface{}, error)
+		want      []int
+		wantPages int
+	}{
+		{
+			name:      "short page",
+			opts:      []PagerOption{WithPageSize(3)},
+			page:      func(page PageRequest) (interface{}, error) { return numbers(page, 7), nil },
+			want:      []int{0, 1, 2, 3, 4, 5, 6},
+			wantPages: 3,
+		},
+		{
+			name:      "empty page",
+			opts:      []PagerOption{WithPageSize(3)},
+			page:      func(page PageRequest) (interface{}, error) { return numbers(page, 6), nil },
+			want:      []int{0, 1, 2, 3, 4, 5},
+			wantPages: 3,
+		},
+		{
+			name: "has_next false",
+			opts: []PagerOption{WithPageSize(2)},
+			page: func(page PageRequest) (interface{}, error) {
+				return map[string]interface{}{
+					"data":       numbers(page, 100),
+					"pagination": map[string]interface{}{"page": page.Page, "has_next": page.Page < 2},
+				}, nil
+			},
+			want:      []int{0, 1, 2, 3},
+			wantPages: 2,
+		},
+		{
+			name: "last page by page count",
+			opts: []PagerOption{WithPageSize(2)},
+			page: func(page PageRequest) (interface{}, error) {
+				return map[string]interface{}{
+					"items":      numbers(page, 100),
+					"pagination": map[string]interface{}{"pages": 3, "has_next": true},
+				}, nil
+			},
+			want:      []int{0, 1, 2, 3, 4, 5},
+			wantPages: 3,
+		},
+		{
+			name: "total",
+			opts: []PagerOption{WithPageSize(2)},
+			page: func(page PageRequest) (interface{}, error) {
+				return map[string]interface{}{"results": numbers(page, 100), "total": 4}, nil
+			},
+			want:      []int{0, 1, 2, 3},
+			wantPages: 2,
+		},
+		{
+			name: "named list",
+			opts: []PagerOption{WithPageSize(2)},
+			page: func(page PageRequest) (interface{}, error) {
+				return map[string]interface{}{"scans": numbers(page, 3), "count": 3}, nil
+			},
+			want:      []int{0, 1, 2},
+			wantPages: 2,
+		},
+		{
+			name:      "max items",
+			opts:      []PagerOption{WithPageSize(3), WithMaxItems(4)},
+			page:      func(page PageRequest) (interface{}, error) { return numbers(page, 100), nil },
+			want:      []int{0, 1, 2, 3},
+			wantPages: 2,
+		},
+		{
+			name:      "max items at a page boundary",
+			opts:      []PagerOption{WithPageSize(3), WithMaxItems(3)},
+			page:      func(page PageRequest) (interface{}, error) { return numbers(page, 100), nil },
+			want:      []int{0, 1, 2},
+			wantPages: 1,
+		},
+		{
+			name:      "not paged",
+			opts:      []PagerOption{WithPageSize(3), withoutPaging()},
+			page:      func(page PageRequest) (interface{}, error) { return numbers(page, 100), nil },
+			want:      []int{0, 1, 2},
+			wantPages: 1,
+		},
+		{
+			name:      "null",
+			page:      func(page PageRequest) (interface{}, error) { return nil, nil },
+			wantPages: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &pageFetcher{page: tt.page}
+			got, err := NewPager[int](fetcher.fetch, tt.opts...).Collect(context.Background())
+			if err != nil {
+				t.Fatalf("Collect: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+			if len(fetcher.requests) != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d: %+v", len(fetcher.requests), tt.wantPages, fetcher.requests)
+			}
+		})
+	}
+}
+
+func TestPagerRequests(t *testing.T) {
+	fetcher := &pageFetcher{page: func(page PageRequest) (interface{}, error) { return numbers(page, 5), nil }}
+	if _, err := NewPager[int](fetcher.fetch, WithPageSize(2)).Collect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := []PageRequest{{Page: 1, Offset: 0, Size: 2}, {Page: 2, Offset: 2, Size: 2}, {Page: 3, Offset: 4, Size: 2}}
+	if !reflect.DeepEqual(fetcher.requests, want) {
+		t.Errorf("requests = %+v, want %+v", fetcher.requests, want)
+	}
+
+	fetcher.requests = nil
+	NewPager[int](fetcher.fetch).Collect(context.Background())
+	if fetcher.requests[0].Size != DefaultPageSize {
+		t.Errorf("page size = %d, want DefaultPageSize", fetcher.requests[0].Size)
+	}
+}
+
+func TestPagerDecodesObjects(t *testing.T) {
+	fetch := func(ctx context.Context, page PageRequest) (interface{}, error) {
+		return jsonPage(`{"data": [{"id": "s1", "status": "completed"}, {"id": "s2", "status": "failed"}], "pagination": {"has_next": false}}`), nil
+	}
+	scans, err := NewPager[Scan](fetch).Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scans) != 2 || scans[0].ID != "s1" || scans[1].Status != "failed" {
+		t.Errorf("scans = %+v", scans)
+	}
+}
+
+func TestPagerErrors(t *testing.T) {
+	failure := errors.New("tavo: HTTP 500")
+	tests := []struct {
+		name      string
+		page      func(page PageRequest) (interface{}, error)
+		wantItems []int
+		wantErr   error
+	}{
+		{
+			name: "fetch error on a later page",
+			page: func(page PageRequest) (interface{}, error) {
+				if page.Page == 2 {
+					return nil, failure
+				}
+				return numbers(page, 100), nil
+			},
+			wantItems: []int{0, 1},
+			wantErr:   failure,
+		},
+		{
+			name: "ambiguous lists",
+			page: func(page PageRequest) (interface{}, error) {
+				return jsonPage(`{"scans": [1], "jobs": [2]}`), nil
+			},
+		},
+		{
+			name: "items of the wrong type",
+			page: func(page PageRequest) (interface{}, error) {
+				return jsonPage(`{"data": ["one"]}`), nil
+			},
+		},
+		{
+			name: "malformed pagination",
+			page: func(page PageRequest) (interface{}, error) {
+				return jsonPage(`{"data": [1], "pagination": "next"}`), nil
+			},
+		},
+		{
+			name: "not a list or object",
+			page: func(page PageRequest) (interface{}, error) { return "page", nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &pageFetcher{page: tt.page}
+			var items []int
+			var errs []error
+			for item, err := range NewPager[int](fetcher.fetch, WithPageSize(2)).All(context.Background()) {
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				items = append(items, item)
+			}
+			if len(errs) != 1 || tt.wantErr != nil && !errors.Is(errs[0], tt.wantErr) {
+				t.Fatalf("errors = %v, want exactly one error", errs)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+
+			collected, err := NewPager[int](fetcher.fetch, WithPageSize(2)).Collect(context.Background())
+			if err == nil || !reflect.DeepEqual(collected, tt.wantItems) {
+				t.Errorf("Collect = %v, %v; want %v and an error", collected, err, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestPagerCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := &pageFetcher{page: func(page PageRequest) (interface{}, error) { return numbers(page, 100), nil }}
+
+	var items []int
+	var gotErr error
+	for item, err := range NewPager[int](fetcher.fetch, WithPageSize(2)).All(ctx) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		items = append(items, item)
+		if item == 2 {
+			cancel()
+		}
+	}
+	// The page being read is finished, but the next one is not fetched
+	if !errors.Is(gotErr, context.Canceled) || !reflect.DeepEqual(items, []int{0, 1, 2, 3}) {
+		t.Errorf("items, err = %v, %v; want [0 1 2 3] and context.Canceled", items, gotErr)
+	}
+	if len(fetcher.requests) != 2 {
+		t.Errorf("fetched %d pages after cancellation, want 2", len(fetcher.requests))
+	}
+
+	cancelled, stop := context.WithCancel(context.Background())
+	stop()
+	fetcher.requests = nil
+	if _, err := NewPager[int](fetcher.fetch).Collect(cancelled); !errors.Is(err, context.Canceled) || len(fetcher.requests) != 0 {
+		t.Errorf("Collect on a cancelled context = %v after %d fetches", err, len(fetcher.requests))
+	}
+}
+
+func TestPagerBreak(t *testing.T) {
+	fetcher := &pageFetcher{page: func(page PageRequest) (interface{}, error) { return numbers(page, 100), nil }}
+	for item := range NewPager[int](fetcher.fetch, WithPageSize(2)).All(context.Background()) {
+		if item == 2 {
+			break
+		}
+	}
+	if len(fetcher.requests) != 2 {
+		t.Errorf("fetched %d pages, want 2", len(fetcher.requests))
+	}
+}
@@ -0,0 +1,97 @@
+package tavo
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pathParamPattern matches {name} and {name:type} placeholders in endpoint paths
+var pathParamPattern = regexp.MustCompile(`\{(\w+)(?::(\w+))?\}`)
+
+// uuidPattern matches canonical textual UUIDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// expandPath substitutes the placeholders in an endpoint path template with
+// escaped values from params. Typed placeholders are validated: {id:uuid} must be
+// a UUID, {n:int} an integer, and {p:path} may span several path segments.
+// Values, or segments of {p:path}, of "." or ".." are rejected.
+func expandPath(template string, params map[string]interface{}) (string, error) {
+	var expandErr error
+	path := pathParamPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if expandErr != nil {
+			return placeholder
+		}
+		match := pathParamPattern.FindStringSubmatch(placeholder)
+		name, kind := match[1], match[2]
+
+		raw, ok := params[name]
+		if !ok {
+			expandErr = fmt.Errorf("missing path parameter %q", name)
+			return placeholder
+		}
+		value := formatPathValue(raw)
+		if value == "" {
+			expandErr = fmt.Errorf("path parameter %q must not be empty", name)
+			return placeholder
+		}
+
+		switch kind {
+		case "uuid":
+			if !uuidPattern.MatchString(value) {
+				expandErr = fmt.Errorf("path parameter %q must be a UUID, got %q", name, value)
+				return placeholder
+			}
+		case "int":
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				expandErr = fmt.Errorf("path parameter %q must be an integer, got %q", name, value)
+				return placeholder
+			}
+		case "path":
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				if isDotSegment(segment) {
+					expandErr = fmt.Errorf("path parameter %q must not contain %q segments", name, segment)
+					return placeholder
+				}
+				segments[i] = url.PathEscape(segment)
+			}
+			return strings.Join(segments, "/")
+		}
+		if isDotSegment(value) {
+			expandErr = fmt.Errorf("path parameter %q must not be %q", name, value)
+			return placeholder
+		}
+		return url.PathEscape(value)
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return path, nil
+}
+
+// isDotSegment reports whether segment would be resolved as a relative path
+func isDotSegment(segment string) bool {
+	return segment == "." || segment == ".."
+}
+
+// formatPathValue renders a path parameter value as a string
+func formatPathValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case float64:
+		return formatNumber(v)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
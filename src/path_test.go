@@ -0,0 +1,63 @@
+package tavo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	id := "3f2b8c1e-4d5a-4b6c-9e7f-0a1b2c3d4e5f"
+	name := "repo name"
+	var nilName *string
+
+	tests := []struct {
+		name     string
+		template string
+		params   map[string]interface{}
+		want     string
+		wantErr  string
+	}{
+		{"no placeholders", "/health", nil, "/health", ""},
+		{"plain", "/scans/{scan_id}", map[string]interface{}{"scan_id": "s-1"}, "/scans/s-1", ""},
+		{"several", "/repos/{owner}/{repo}/scans", map[string]interface{}{"owner": "acme", "repo": "api"}, "/repos/acme/api/scans", ""},
+		{"reserved characters", "/rules/{rule_id}", map[string]interface{}{"rule_id": "a/b?c#d%e f"}, "/rules/a%2Fb%3Fc%23d%25e%20f", ""},
+		{"dots in a value", "/rules/{rule_id}", map[string]interface{}{"rule_id": "..."}, "/rules/...", ""},
+		{"unicode", "/plugins/{name}", map[string]interface{}{"name": "über"}, "/plugins/%C3%BCber", ""},
+		{"pointer", "/repos/{name}", map[string]interface{}{"name": &name}, "/repos/repo%20name", ""},
+		{"float that is an integer", "/pages/{n}", map[string]interface{}{"n": float64(3)}, "/pages/3", ""},
+		{"uuid", "/scans/{scan_id:uuid}", map[string]interface{}{"scan_id": id}, "/scans/" + id, ""},
+		{"uppercase uuid", "/scans/{scan_id:uuid}", map[string]interface{}{"scan_id": strings.ToUpper(id)}, "/scans/" + strings.ToUpper(id), ""},
+		{"int", "/jobs/{n:int}", map[string]interface{}{"n": 42}, "/jobs/42", ""},
+		{"negative int", "/jobs/{n:int}", map[string]interface{}{"n": "-7"}, "/jobs/-7", ""},
+		{"path", "/files/{file:path}", map[string]interface{}{"file": "src/a b/main.go"}, "/files/src/a%20b/main.go", ""},
+		{"unknown type", "/x/{v:other}", map[string]interface{}{"v": "a/b"}, "/x/a%2Fb", ""},
+		{"extra params", "/scans/{scan_id}", map[string]interface{}{"scan_id": "s", "unused": "x"}, "/scans/s", ""},
+
+		{"missing", "/scans/{scan_id}", map[string]interface{}{"id": "s"}, "", `missing path parameter "scan_id"`},
+		{"nil params", "/scans/{scan_id}", nil, "", `missing path parameter "scan_id"`},
+		{"second missing", "/repos/{owner}/{repo}", map[string]interface{}{"owner": "acme"}, "", `missing path parameter "repo"`},
+		{"empty", "/scans/{scan_id}", map[string]interface{}{"scan_id": ""}, "", "must not be empty"},
+		{"nil pointer", "/repos/{name}", map[string]interface{}{"name": nilName}, "", "must not be empty"},
+		{"invalid uuid", "/scans/{scan_id:uuid}", map[string]interface{}{"scan_id": "not-a-uuid"}, "", "must be a UUID"},
+		{"uuid with suffix", "/scans/{scan_id:uuid}", map[string]interface{}{"scan_id": id + "/../x"}, "", "must be a UUID"},
+		{"dot segment", "/rules/{rule_id}", map[string]interface{}{"rule_id": ".."}, "", `must not be ".."`},
+		{"dot segment in path", "/files/{file:path}", map[string]interface{}{"file": "src/../../etc"}, "", `must not contain ".." segments`},
+		{"current directory", "/rules/{rule_id}", map[string]interface{}{"rule_id": "."}, "", `must not be "."`},
+		{"invalid int", "/jobs/{n:int}", map[string]interface{}{"n": "4a"}, "", "must be an integer"},
+		{"fractional int", "/jobs/{n:int}", map[string]interface{}{"n": 1.5}, "", "must be an integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPath(tt.template, tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expandPath = %q, %v; want an error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("expandPath = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LockfileName is the conventional name of a plugin lockfile
+const LockfileName = "tavo.lock"
+
+// lockfileVersion is the format version written to new lockfiles
+const lockfileVersion = 1
+
+// ErrPluginNotCached is returned when a locked plugin is missing from the cache
+// and no PluginFetcher is available to download it
+var ErrPluginNotCached = errors.New("plugin is not in the local cache")
+
+// ErrPluginChecksumMismatch is returned when a plugin archive does not match its locked checksum
+var ErrPluginChecksumMismatch = errors.New("plugin checksum does not match the lockfile")
+
+// PluginFetcher downloads plugin archives from the marketplace.
+// *tavo.PluginMarketplaceClient implements it.
+type PluginFetcher interface {
+	// FetchPlugin writes the archive of a plugin version into w and returns the
+	// version written. An empty version requests the latest release.
+	FetchPlugin(ctx context.Context, pluginID, version string, w io.Writer) (string, error)
+}
+
+// LockedPlugin pins one plugin to an exact version and archive checksum
+type LockedPlugin struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	SHA256  string `json:"sha256"`
+}
+
+// Lockfile records the plugins a scanner setup uses
+type Lockfile struct {
+	LockfileVersion int            `json:"lockfile_version"`
+	Plugins         []LockedPlugin `json:"plugins"`
+}
+
+// LoadLockfile reads a lockfile. A missing file yields an empty lockfile.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Lockfile{LockfileVersion: lockfileVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parse lockfile %s: %w", path, err)
+	}
+	if lock.LockfileVersion > lockfileVersion {
+		return nil, fmt.Errorf("lockfile %s has unsupported version %d", path, lock.LockfileVersion)
+	}
+	if err := lock.validate(); err != nil {
+		return nil, fmt.Errorf("lockfile %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// validate checks that every entry names a plugin and pins a SHA-256 checksum
+func (l *Lockfile) validate() error {
+	for _, plugin := range l.Plugins {
+		if plugin.ID == "" {
+			return errors.New("plugin entry without an id")
+		}
+		if len(plugin.SHA256) != sha256.Size*2 {
+			return fmt.Errorf("plugin %s has no valid sha256 checksum", plugin.ID)
+		}
+		if _, err := hex.DecodeString(plugin.SHA256); err != nil {
+			return fmt.Errorf("plugin %s has no valid sha256 checksum", plugin.ID)
+		}
+		if strings.ContainsAny(plugin.ID+plugin.Version, `/\`) {
+			return fmt.Errorf("plugin %s %s is not a valid file name", plugin.ID, plugin.Version)
+		}
+	}
+	return nil
+}
+
+// uses reports whether any entry pins the archive with the given checksum
+func (l *Lockfile) uses(sum string) bool {
+	for _, plugin := range l.Plugins {
+		if plugin.SHA256 == sum {
+			return true
+		}
+	}
+	return false
+}
+
+// Save writes the lockfile to path, sorted by plugin ID so diffs stay stable
+func (l *Lockfile) Save(path string) error {
+	sort.Slice(l.Plugins, func(i, j int) bool { return l.Plugins[i].ID < l.Plugins[j].ID })
+	if l.LockfileVersion == 0 {
+		l.LockfileVersion = lockfileVersion
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'), 0o644)
+}
+
+// Find returns the locked entry for a plugin, or nil
+func (l *Lockfile) Find(pluginID string) *LockedPlugin {
+	for i := range l.Plugins {
+		if l.Plugins[i].ID == pluginID {
+			return &l.Plugins[i]
+		}
+	}
+	return nil
+}
+
+// Ref returns the name the scanner loads the plugin by, "<id>@<version>"
+func (p LockedPlugin) Ref() string {
+	return p.ID + "@" + p.Version
+}
+
+// set adds or replaces the entry for a plugin
+func (l *Lockfile) set(plugin LockedPlugin) {
+	if existing := l.Find(plugin.ID); existing != nil {
+		*existing = plugin
+		return
+	}
+	l.Plugins = append(l.Plugins, plugin)
+}
+
+// PluginCache is a content-addressed store of plugin archives, keyed by their SHA-256
+type PluginCache struct {
+	dir string
+
+	mu       sync.Mutex
+	verified map[string]fileStamp // archives hashed since they last changed
+}
+
+// fileStamp identifies the state of a file when it was hashed
+type fileStamp struct {
+	size    int64
+	modTime int64
+}
+
+// stampOf returns the stamp of the file at path
+func stampOf(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}, nil
+}
+
+// DefaultPluginCacheDir returns the cache location: $TAVO_PLUGIN_CACHE if set,
+// otherwise a "tavo/plugins" directory in the user cache directory
+func DefaultPluginCacheDir() (string, error) {
+	if dir := os.Getenv("TAVO_PLUGIN_CACHE"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tavo", "plugins"), nil
+}
+
+// NewPluginCache opens the cache in dir, creating it if needed. An empty dir
+// uses DefaultPluginCacheDir.
+func NewPluginCache(dir string) (*PluginCache, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultPluginCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &PluginCache{dir: dir, verified: make(map[string]fileStamp)}, nil
+}
+
+// Dir returns the cache directory
+func (c *PluginCache) Dir() string {
+	return c.dir
+}
+
+// Path returns where the archive with the given checksum is stored
+func (c *PluginCache) Path(sum string) string {
+	prefix := sum
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(c.dir, "sha256", prefix, sum)
+}
+
+// PluginDir returns the directory where Configure links archives as
+// "<id>@<version>" for the scanner to load
+func (c *PluginCache) PluginDir() string {
+	return filepath.Join(c.dir, "installed")
+}
+
+// Has reports whether the archive with the given checksum is cached and intact.
+// An archive is hashed again only when its size or modification time changed
+// since it was last verified.
+func (c *PluginCache) Has(sum string) bool {
+	path := c.Path(sum)
+	stamp, err := stampOf(path)
+	if err != nil {
+		return false
+	}
+	c.mu.Lock()
+	verified, ok := c.verified[sum]
+	c.mu.Unlock()
+	if ok && verified == stamp {
+		return true
+	}
+
+	actual, err := fileSHA256(path)
+	if err != nil || actual != sum {
+		return false
+	}
+	c.remember(sum, stamp)
+	return true
+}
+
+// remember records the stamp of an archive whose checksum was verified
+func (c *PluginCache) remember(sum string, stamp fileStamp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.verified == nil {
+		c.verified = make(map[string]fileStamp)
+	}
+	c.verified[sum] = stamp
+}
+
+// Put stores the content of r and returns its checksum and cache path
+func (c *PluginCache) Put(r io.Reader) (sum, path string, err error) {
+	tmp, err := os.CreateTemp(c.dir, ".download-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), r); err != nil {
+		tmp.Close()
+		return "", "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", "", err
+	}
+
+	sum = hex.EncodeToString(hash.Sum(nil))
+	path = c.Path(sum)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", "", err
+	}
+	if stamp, err := stampOf(path); err == nil {
+		c.remember(sum, stamp)
+	}
+	return sum, path, nil
+}
+
+// link makes the archive of a locked plugin available in PluginDir under its Ref
+func (c *PluginCache) link(plugin LockedPlugin) error {
+	archive := c.Path(plugin.SHA256)
+	path := filepath.Join(c.PluginDir(), plugin.Ref())
+	if linked, err := os.Stat(path); err == nil {
+		if target, err := os.Stat(archive); err == nil && os.SameFile(linked, target) {
+			return nil
+		}
+	}
+	if err := os.MkdirAll(c.PluginDir(), 0o755); err != nil {
+		return err
+	}
+
+	// Link under a temporary name and rename it, replacing an outdated link
+	tmp, err := os.CreateTemp(c.PluginDir(), ".link-*")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	os.Remove(tmp.Name())
+	if err := os.Link(archive, tmp.Name()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// fetch downloads a plugin version into the cache
+func (c *PluginCache) fetch(ctx context.Context, fetcher PluginFetcher, pluginID, version string) (string, string, error) {
+	tmp, err := os.CreateTemp(c.dir, ".download-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	resolved, err := fetcher.FetchPlugin(ctx, pluginID, version, tmp)
+	if err != nil {
+		return "", "", fmt.Errorf("download plugin %s: %w", pluginID, err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
+	}
+	sum, _, err := c.Put(tmp)
+	return resolved, sum, err
+}
+
+// PluginManager installs marketplace plugins into a PluginCache and pins them
+// in a lockfile, so every machine scans with the same plugin archives
+type PluginManager struct {
+	cache        *PluginCache
+	fetcher      PluginFetcher
+	lockfilePath string
+	lock         *Lockfile
+}
+
+// NewPluginManager creates a manager for the lockfile at lockfilePath. A nil
+// fetcher works offline, using only archives that are already cached.
+func NewPluginManager(cache *PluginCache, fetcher PluginFetcher, lockfilePath string) (*PluginManager, error) {
+	if lockfilePath == "" {
+		lockfilePath = LockfileName
+	}
+	lock, err := LoadLockfile(lockfilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &PluginManager{cache: cache, fetcher: fetcher, lockfilePath: lockfilePath, lock: lock}, nil
+}
+
+// Lockfile returns the manager's lockfile
+func (m *PluginManager) Lockfile() *Lockfile {
+	return m.lock
+}
+
+// Add downloads a plugin version, or the latest one if version is empty, and
+// pins the version it resolved to in the lockfile. The marketplace client also
+// accepts constraints such as "^1.2".
+func (m *PluginManager) Add(ctx context.Context, pluginID, version string) (*LockedPlugin, error) {
+	if m.fetcher == nil {
+		return nil, fmt.Errorf("add plugin %s: no PluginFetcher configured", pluginID)
+	}
+	resolved, sum, err := m.cache.fetch(ctx, m.fetcher, pluginID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.set(LockedPlugin{ID: pluginID, Version: resolved, SHA256: sum})
+	if err := m.lock.Save(m.lockfilePath); err != nil {
+		return nil, err
+	}
+	return m.lock.Find(pluginID), nil
+}
+
+// Remove unpins a plugin. Its archive stays in the cache.
+func (m *PluginManager) Remove(pluginID string) error {
+	for i, plugin := range m.lock.Plugins {
+		if plugin.ID == pluginID {
+			m.lock.Plugins = append(m.lock.Plugins[:i], m.lock.Plugins[i+1:]...)
+			return m.lock.Save(m.lockfilePath)
+		}
+	}
+	return nil
+}
+
+// Sync makes sure every locked plugin is in the cache, downloading missing
+// archives when a fetcher is available. Downloaded archives must match the
+// locked checksum.
+func (m *PluginManager) Sync(ctx context.Context) error {
+	if err := m.lock.validate(); err != nil {
+		return err
+	}
+	for _, plugin := range m.lock.Plugins {
+		if m.cache.Has(plugin.SHA256) {
+			continue
+		}
+		if m.fetcher == nil {
+			return fmt.Errorf("%w: %s %s", ErrPluginNotCached, plugin.ID, plugin.Version)
+		}
+		_, sum, err := m.cache.fetch(ctx, m.fetcher, plugin.ID, plugin.Version)
+		if err != nil {
+			return err
+		}
+		if sum != plugin.SHA256 {
+			// Keep the archive if another entry pins it
+			if !m.lock.uses(sum) {
+				os.Remove(m.cache.Path(sum))
+			}
+			return fmt.Errorf("%w: %s %s is %s, locked %s", ErrPluginChecksumMismatch, plugin.ID, plugin.Version, sum, plugin.SHA256)
+		}
+	}
+	return nil
+}
+
+// Paths returns the cached archive of every locked plugin, in lockfile order
+func (m *PluginManager) Paths() ([]string, error) {
+	if err := m.lock.validate(); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(m.lock.Plugins))
+	for _, plugin := range m.lock.Plugins {
+		if !m.cache.Has(plugin.SHA256) {
+			return nil, fmt.Errorf("%w: %s %s", ErrPluginNotCached, plugin.ID, plugin.Version)
+		}
+		paths = append(paths, m.cache.Path(plugin.SHA256))
+	}
+	return paths, nil
+}
+
+// Configure adds the locked plugins to config.Plugins as "<id>@<version>",
+// replacing entries for the same plugin, and points config.PluginDir at the
+// cache. Call Sync first.
+func (m *PluginManager) Configure(config *ScannerConfig) error {
+	if _, err := m.Paths(); err != nil {
+		return err
+	}
+	for _, plugin := range m.lock.Plugins {
+		if err := m.cache.link(plugin); err != nil {
+			return fmt.Errorf("install plugin %s: %w", plugin.ID, err)
+		}
+		config.Plugins = pinPlugin(config.Plugins, plugin)
+	}
+	config.PluginDir = m.cache.PluginDir()
+	return nil
+}
+
+// pinPlugin replaces the entries naming a plugin, with or without a version,
+// by the plugin's Ref, appending it if there were none
+func pinPlugin(plugins []string, plugin LockedPlugin) []string {
+	pinned := make([]string, 0, len(plugins)+1)
+	added := false
+	for _, name := range plugins {
+		if id, _, _ := strings.Cut(name, "@"); id != plugin.ID {
+			pinned = append(pinned, name)
+			continue
+		}
+		if !added {
+			pinned = append(pinned, plugin.Ref())
+			added = true
+		}
+	}
+	if !added {
+		pinned = append(pinned, plugin.Ref())
+	}
+	return pinned
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the same directory
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
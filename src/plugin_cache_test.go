@@ -0,0 +1,281 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeFetcher serves archives whose content is derived from the plugin and version
+type fakeFetcher struct {
+	calls   int
+	content func(pluginID, version string) string
+}
+
+func (f *fakeFetcher) FetchPlugin(ctx context.Context, pluginID, version string, w io.Writer) (string, error) {
+	f.calls++
+	if version == "" {
+		version = "2.0.0"
+	}
+	_, err := io.WriteString(w, f.content(pluginID, version))
+	return version, err
+}
+
+// archiveFetcher returns a fetcher that serves a distinct archive per plugin version
+func archiveFetcher() *fakeFetcher {
+	return &fakeFetcher{content: func(pluginID, version string) string { return "archive " + pluginID + "@" + version }}
+}
+
+// checksum returns the hex SHA-256 of content
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// newTestManager creates a manager with its own cache and lockfile
+func newTestManager(t *testing.T, fetcher PluginFetcher) (*PluginManager, *PluginCache, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cache, err := NewPluginCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockPath := filepath.Join(dir, LockfileName)
+	manager, err := NewPluginManager(cache, fetcher, lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manager, cache, lockPath
+}
+
+func TestPluginManagerAddAndSync(t *testing.T) {
+	ctx := context.Background()
+	fetcher := archiveFetcher()
+	manager, cache, lockPath := newTestManager(t, fetcher)
+
+	locked, err := manager.Add(ctx, "b-plugin", "")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if locked.Version != "2.0.0" || locked.SHA256 != checksum("archive b-plugin@2.0.0") || !cache.Has(locked.SHA256) {
+		t.Errorf("locked = %+v", locked)
+	}
+	if _, err := manager.Add(ctx, "a-plugin", "1.0.0"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	lock, err := LoadLockfile(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Plugins) != 2 || lock.Plugins[0].ID != "a-plugin" {
+		t.Errorf("lockfile = %+v, want two plugins sorted by id", lock.Plugins)
+	}
+
+	// Offline on an empty cache
+	emptyCache, err := NewPluginCache(filepath.Join(t.TempDir(), "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, err := NewPluginManager(emptyCache, nil, lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := offline.Sync(ctx); !errors.Is(err, ErrPluginNotCached) {
+		t.Errorf("offline Sync = %v, want ErrPluginNotCached", err)
+	}
+
+	// Online on the same cache downloads what is missing
+	online, err := NewPluginManager(emptyCache, fetcher, lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := online.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if err := offline.Sync(ctx); err != nil {
+		t.Errorf("offline Sync after download: %v", err)
+	}
+}
+
+func TestPluginManagerConfigurePinsPlugins(t *testing.T) {
+	ctx := context.Background()
+	manager, cache, _ := newTestManager(t, archiveFetcher())
+	if _, err := manager.Add(ctx, "sql-injection", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Add(ctx, "xss", "2.1.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ScannerConfig{Plugins: []string{"secrets", "xss", "xss@1.0.0"}}
+	for i := 0; i < 2; i++ {
+		if err := manager.Configure(config); err != nil {
+			t.Fatalf("Configure: %v", err)
+		}
+	}
+	want := []string{"secrets", "xss@2.1.0", "sql-injection@1.0.0"}
+	if strings.Join(config.Plugins, " ") != strings.Join(want, " ") {
+		t.Errorf("Plugins = %q, want %q", config.Plugins, want)
+	}
+	if config.PluginDir != cache.PluginDir() {
+		t.Errorf("PluginDir = %q, want %q", config.PluginDir, cache.PluginDir())
+	}
+	for _, ref := range []string{"sql-injection@1.0.0", "xss@2.1.0"} {
+		content, err := os.ReadFile(filepath.Join(cache.PluginDir(), ref))
+		if err != nil || string(content) != "archive "+ref {
+			t.Errorf("%s in the plugin dir = %q, %v", ref, content, err)
+		}
+	}
+
+	args := scanArgs(".", config, nil, &runFiles{})
+	if got, want := strings.Join(args, " "), ". --plugin-dir "+cache.PluginDir()+" --plugin secrets --plugin xss@2.1.0 --plugin sql-injection@1.0.0"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestPluginManagerConfigureRelinksUpdatedPlugin(t *testing.T) {
+	ctx := context.Background()
+	versions := map[string]string{"1.0.0": "first build", "1.0.0-rebuilt": "second build"}
+	manager, cache, _ := newTestManager(t, &fakeFetcher{content: func(pluginID, version string) string { return versions[version] }})
+	if _, err := manager.Add(ctx, "p", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Configure(&ScannerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lockfile now pins another archive under the same version
+	manager.lock.Plugins[0].SHA256 = checksum(versions["1.0.0-rebuilt"])
+	if _, _, err := cache.Put(strings.NewReader(versions["1.0.0-rebuilt"])); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Configure(&ScannerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if content, err := os.ReadFile(filepath.Join(cache.PluginDir(), "p@1.0.0")); err != nil || string(content) != "second build" {
+		t.Errorf("p@1.0.0 = %q, %v; want the newly locked archive", content, err)
+	}
+}
+
+func TestPluginCacheHas(t *testing.T) {
+	cache, err := NewPluginCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, path, err := cache.Put(strings.NewReader("archive"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Has(sum) {
+		t.Fatal("Has = false for a stored archive")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged size and modification time are trusted without hashing again
+	if err := os.WriteFile(path, []byte("tamper!"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Has(sum) {
+		t.Error("Has hashed an archive whose size and modification time did not change")
+	}
+
+	// A changed modification time makes Has verify the content
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Has(sum) {
+		t.Error("Has = true for a modified archive")
+	}
+
+	if err := os.WriteFile(path, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Has(sum) {
+		t.Error("Has = false after restoring the archive")
+	}
+	os.Remove(path)
+	if cache.Has(sum) {
+		t.Error("Has = true for a removed archive")
+	}
+}
+
+func TestPluginManagerSyncKeepsSharedArchiveOnMismatch(t *testing.T) {
+	ctx := context.Background()
+	shared := "shared archive"
+	manager, cache, _ := newTestManager(t, &fakeFetcher{content: func(pluginID, version string) string { return shared }})
+
+	sum, _, err := cache.Put(strings.NewReader(shared))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.lock.Plugins = []LockedPlugin{
+		{ID: "alias", Version: "1.0.0", SHA256: sum},
+		{ID: "broken", Version: "1.0.0", SHA256: checksum("what was locked")},
+	}
+
+	if err := manager.Sync(ctx); !errors.Is(err, ErrPluginChecksumMismatch) {
+		t.Fatalf("Sync = %v, want ErrPluginChecksumMismatch", err)
+	}
+	if !cache.Has(sum) {
+		t.Error("Sync removed an archive that another entry pins")
+	}
+}
+
+func TestPluginManagerSyncRemovesUnusedArchiveOnMismatch(t *testing.T) {
+	ctx := context.Background()
+	manager, cache, _ := newTestManager(t, archiveFetcher())
+	manager.lock.Plugins = []LockedPlugin{{ID: "broken", Version: "1.0.0", SHA256: checksum("what was locked")}}
+
+	if err := manager.Sync(ctx); !errors.Is(err, ErrPluginChecksumMismatch) {
+		t.Fatalf("Sync = %v, want ErrPluginChecksumMismatch", err)
+	}
+	if cache.Has(checksum("archive broken@1.0.0")) {
+		t.Error("Sync kept the mismatching archive")
+	}
+}
+
+func TestLockfileRejectsInvalidChecksums(t *testing.T) {
+	for name, entry := range map[string]string{
+		"empty":      `{"id": "p", "version": "1.0.0", "sha256": ""}`,
+		"missing":    `{"id": "p", "version": "1.0.0"}`,
+		"short":      `{"id": "p", "version": "1.0.0", "sha256": "abc123"}`,
+		"not hex":    `{"id": "p", "version": "1.0.0", "sha256": "` + strings.Repeat("z", 64) + `"}`,
+		"traversal":  `{"id": "p", "version": "1.0.0", "sha256": "../../../../../../../../../../../../../../../../../../../../etc/x"}`,
+		"no id":      `{"version": "1.0.0", "sha256": "` + checksum("x") + `"}`,
+		"path in id": `{"id": "../p", "version": "1.0.0", "sha256": "` + checksum("x") + `"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), LockfileName)
+			if err := os.WriteFile(path, []byte(`{"lockfile_version": 1, "plugins": [`+entry+`]}`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadLockfile(path); err == nil {
+				t.Error("LoadLockfile accepted the entry")
+			}
+		})
+	}
+
+	manager, _, _ := newTestManager(t, archiveFetcher())
+	manager.lock.Plugins = []LockedPlugin{{ID: "p", Version: "1.0.0"}}
+	if err := manager.Sync(context.Background()); err == nil {
+		t.Error("Sync accepted an entry without a checksum")
+	}
+	if _, err := manager.Paths(); err == nil {
+		t.Error("Paths accepted an entry without a checksum")
+	}
+}
@@ -0,0 +1,109 @@
+package tavo
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// queryBuilder accumulates query parameters for an endpoint call. Unset optional
+// parameters (nil pointers) are skipped, pointers are dereferenced and slices are
+// encoded as repeated keys.
+type queryBuilder struct {
+	values url.Values
+}
+
+// newQuery returns an empty queryBuilder
+func newQuery() *queryBuilder {
+	return &queryBuilder{values: url.Values{}}
+}
+
+// Add appends value under key unless it is unset
+func (q *queryBuilder) Add(key string, value interface{}) *queryBuilder {
+	switch v := value.(type) {
+	case nil:
+	case *string:
+		if v != nil {
+			q.values.Add(key, *v)
+		}
+	case *bool:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case *int:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case *int64:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case *float64:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case *time.Time:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case *[]string:
+		if v != nil {
+			q.Add(key, *v)
+		}
+	case []string:
+		for _, item := range v {
+			q.values.Add(key, item)
+		}
+	case string:
+		q.values.Add(key, v)
+	case bool:
+		q.values.Add(key, strconv.FormatBool(v))
+	case int:
+		q.values.Add(key, strconv.Itoa(v))
+	case int64:
+		q.values.Add(key, strconv.FormatInt(v, 10))
+	case float64:
+		q.values.Add(key, formatNumber(v))
+	case time.Time:
+		if !v.IsZero() {
+			q.values.Add(key, v.UTC().Format(time.RFC3339))
+		}
+	case fmt.Stringer:
+		q.values.Add(key, v.String())
+	default:
+		// Pointers to and slices of other types, e.g. named string types
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Pointer:
+			if !rv.IsNil() {
+				q.Add(key, rv.Elem().Interface())
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				q.Add(key, rv.Index(i).Interface())
+			}
+		default:
+			q.values.Add(key, fmt.Sprint(v))
+		}
+	}
+	return q
+}
+
+// Values returns the accumulated parameters, or nil when none were added
+func (q *queryBuilder) Values() url.Values {
+	if len(q.values) == 0 {
+		return nil
+	}
+	return q.values
+}
+
+// formatNumber renders whole numbers without a fractional part or exponent
+func formatNumber(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
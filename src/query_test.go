@@ -0,0 +1,54 @@
+package tavo
+
+import (
+	"testing"
+	"time"
+)
+
+// scanKind is a named type without a case of its own in queryBuilder.Add
+type scanKind string
+
+func TestQueryBuilderAdd(t *testing.T) {
+	var unset *string
+	limit := 20.0
+	ids := []string{"a", "b"}
+	archived := false
+	kind := scanKind("full")
+	var unsetKind *scanKind
+	count := int32(7)
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"nil pointer", unset, ""},
+		{"nil", nil, ""},
+		{"float pointer", &limit, "v=20"},
+		{"fraction", 1.5, "v=1.5"},
+		{"slice pointer", &ids, "v=a&v=b"},
+		{"bool pointer", &archived, "v=false"},
+		{"time", since, "v=2024-05-01T10%3A00%3A00Z"},
+		{"zero time", time.Time{}, ""},
+		{"named type", kind, "v=full"},
+		{"named type pointer", &kind, "v=full"},
+		{"nil named type pointer", unsetKind, ""},
+		{"other int pointer", &count, "v=7"},
+		{"other slice", []scanKind{"quick", "full"}, "v=quick&v=full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newQuery().Add("v", tt.value).Values().Encode(); got != tt.want {
+				t.Errorf("Add(%#v) encodes as %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderValuesEmpty(t *testing.T) {
+	var unset *string
+	if values := newQuery().Add("s", unset).Values(); values != nil {
+		t.Errorf("Values() = %v, want nil", values)
+	}
+}
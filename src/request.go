@@ -0,0 +1,194 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// userAgent identifies the SDK to the Tavo API
+const userAgent = "tavo-sdk-go/0.1.0"
+
+// streamBody is a request payload streamed as-is rather than encoded as JSON
+type streamBody interface {
+	// contentType returns the Content-Type header sent with the payload
+	contentType() string
+
+	// open is called once per attempt and returns a reader positioned at the start
+	open() (io.ReadCloser, error)
+}
+
+// newRequest builds an authenticated JSON request for a path relative to the API root.
+// A nil body sends no payload, a streamBody is sent unchanged and anything else
+// is encoded as JSON.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fullURL := c.baseURL + c.apiPath + path
+	if len(query) > 0 {
+		fullURL += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	contentType := "application/json"
+	switch body := body.(type) {
+	case nil:
+	case streamBody:
+		rc, err := body.open()
+		if err != nil {
+			return nil, err
+		}
+		reader = rc
+		contentType = body.contentType()
+	default:
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
+	if err != nil {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", c.userAgent)
+	if key := idempotencyKey(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	c.authorize(req)
+	// Headers passed for a single call take precedence over the client's credentials
+	for key, values := range requestHeaders(ctx) {
+		req.Header[key] = values
+	}
+
+	return req, nil
+}
+
+// requestHeadersContextKey is the context key for extra request headers
+type requestHeadersContextKey struct{}
+
+// withRequestHeader returns a context that adds a header to requests built with it
+func withRequestHeader(ctx context.Context, key, value string) context.Context {
+	headers := requestHeaders(ctx).Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Set(key, value)
+	return context.WithValue(ctx, requestHeadersContextKey{}, headers)
+}
+
+// requestHeaders returns the extra request headers stored in ctx, if any
+func requestHeaders(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(requestHeadersContextKey{}).(http.Header)
+	return headers
+}
+
+// authorize attaches the configured credentials to req
+func (c *Client) authorize(req *http.Request) {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	} else if c.deviceToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.deviceToken)
+	}
+}
+
+// do sends an API request and decodes the JSON response into out.
+// out may be nil when the caller does not need the response body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(bodyBytes) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bodyBytes, out)
+}
+
+// send performs an API request, retrying transient failures according to the
+// client's RetryPolicy. It returns the first 2xx response, whose body the caller
+// must close, or an error. Non-2xx responses are returned as *APIError.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	policy := c.policy()
+
+	reauthenticated := false
+	for attempt := 1; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, query, body)
+		if err != nil {
+			return nil, err
+		}
+		retriesLeft := attempt < policy.MaxAttempts && canRetry(req)
+		token := c.credentialToken()
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if !retriesLeft || isContextError(err) {
+				return nil, err
+			}
+			if err := sleep(req.Context(), policy.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		apiErr := newAPIError(resp)
+		resp.Body.Close()
+
+		// Rejected credentials are refreshed once; the request was not
+		// processed, so it is safe to resend whatever its method
+		if resp.StatusCode == http.StatusUnauthorized && !reauthenticated && c.canReauthenticate(ctx) {
+			reauthenticated = true
+			if err := c.reauthenticate(ctx, token); err != nil {
+				return nil, c.unauthorized(apiErr)
+			}
+			attempt--
+			continue
+		}
+
+		if !retriesLeft || !policy.retryableStatus(resp.StatusCode) {
+			return nil, c.unauthorized(apiErr)
+		}
+
+		delay := policy.backoff(attempt)
+		if apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+			if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+				delay = policy.MaxBackoff
+			}
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// decodeResult converts a loosely typed endpoint result into out
+func decodeResult(result interface{}, out interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
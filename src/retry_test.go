@@ -0,0 +1,230 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetries retries quickly so tests do not wait on the default backoff
+func fastRetries() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Second
+	policy.Jitter = 0
+	return policy
+}
+
+// newTestClient starts a server for handler and returns a client pointed at it
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts = append([]Option{WithRetryPolicy(fastRetries())}, opts...)
+	return NewClient("test-key", "", server.URL, opts...)
+}
+
+// failFirst answers the first n requests with status and headers, then 200
+func failFirst(n int32, status int, headers map[string]string, calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"2", 2 * time.Second, 2 * time.Second, true},
+		{"-1", 0, 0, false},
+		{"soon", 0, 0, false},
+		{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 8 * time.Second, 10 * time.Second, true},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0, true},
+	}
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value)
+		if ok != tt.ok || delay < tt.min || delay > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v..%v, %v", tt.value, delay, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+}
+
+func TestSendRetriesHonourRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter func() string
+		minDelay   time.Duration
+	}{
+		{"429 seconds", http.StatusTooManyRequests, func() string { return "1" }, 900 * time.Millisecond},
+		{"503 seconds", http.StatusServiceUnavailable, func() string { return "1" }, 900 * time.Millisecond},
+		{"429 http date", http.StatusTooManyRequests, func() string {
+			return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+		}, 500 * time.Millisecond},
+		{"503 http date", http.StatusServiceUnavailable, func() string {
+			return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+		}, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				failFirst(1, tt.status, map[string]string{"Retry-After": tt.retryAfter()}, &calls)(w, r)
+			})
+
+			start := time.Now()
+			var out map[string]interface{}
+			if err := client.do(context.Background(), http.MethodGet, "/health", nil, nil, &out); err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minDelay {
+				t.Errorf("retried after %v, want at least %v", elapsed, tt.minDelay)
+			}
+			if calls.Load() != 2 || out["status"] != "ok" {
+				t.Errorf("calls = %d, out = %v", calls.Load(), out)
+			}
+		})
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, failFirst(100, http.StatusBadGateway, nil, &calls))
+
+	err := client.do(context.Background(), http.MethodGet, "/health", nil, nil, nil)
+	if !IsServerError(err) {
+		t.Fatalf("err = %v, want a server error", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestSendRetriesPostOnlyWithIdempotencyKey(t *testing.T) {
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var keys []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		failFirst(1, http.StatusServiceUnavailable, nil, &calls)(w, r)
+	})
+	body := map[string]string{"name": "scan"}
+
+	err := client.do(context.Background(), http.MethodPost, "/scans", nil, body, nil)
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want HTTP 503", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("POST without Idempotency-Key sent %d times, want 1", calls.Load())
+	}
+
+	calls.Store(0)
+	mu.Lock()
+	keys = nil
+	mu.Unlock()
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	if err := client.do(ctx, http.MethodPost, "/scans", nil, body, nil); err != nil {
+		t.Fatalf("do with Idempotency-Key: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("POST with Idempotency-Key sent %d times, want 2", calls.Load())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, key := range keys {
+		if key != "key-1" {
+			t.Errorf("Idempotency-Key = %q, want key-1", key)
+		}
+	}
+}
+
+func TestSendCancelledDuringBackoff(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, failFirst(100, http.StatusTooManyRequests, map[string]string{"Retry-After": "60"}, &calls),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, RetryableStatuses: []int{http.StatusTooManyRequests}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := client.do(ctx, http.MethodGet, "/health", nil, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %v", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestSendReauthenticatesOnce(t *testing.T) {
+	var calls, reauths atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithReauthenticator(func(ctx context.Context, client *Client) error {
+		client.SetAPIKey("key-" + strconv.Itoa(int(reauths.Add(1))))
+		return nil
+	}))
+
+	done := make(chan error, 1)
+	go func() { done <- client.do(context.Background(), http.MethodGet, "/health", nil, nil, nil) }()
+
+	select {
+	case err := <-done:
+		if !IsUnauthorized(err) {
+			t.Fatalf("err = %v, want HTTP 401", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request kept reauthenticating")
+	}
+	if reauths.Load() != 1 || calls.Load() != 2 {
+		t.Errorf("reauths = %d, calls = %d; want 1 and 2", reauths.Load(), calls.Load())
+	}
+}
+
+func TestSendRetriesWithRefreshedCredentials(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("X-API-Key") != "fresh-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}, WithReauthenticator(func(ctx context.Context, client *Client) error {
+		client.SetAPIKey("fresh-key")
+		return nil
+	}))
+
+	// A POST is resent after a 401 because the server did not process it
+	if err := client.do(context.Background(), http.MethodPost, "/scans", nil, map[string]string{}, nil); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+}
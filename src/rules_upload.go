@@ -0,0 +1,284 @@
+package tavo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidRulesFile is returned when a rules file fails validation before upload
+var ErrInvalidRulesFile = errors.New("tavo: invalid rules file")
+
+// rulesSniffLength is how much of a rules file is inspected before upload
+const rulesSniffLength = 64 * 1024
+
+// RulesFormat is the format of a rules file
+type RulesFormat string
+
+const (
+	RulesFormatYAML    RulesFormat = "yaml"
+	RulesFormatJSON    RulesFormat = "json"
+	RulesFormatSemgrep RulesFormat = "semgrep"
+)
+
+// rulesExtensions maps accepted rules file extensions to their base format
+var rulesExtensions = map[string]RulesFormat{
+	".yaml": RulesFormatYAML,
+	".yml":  RulesFormatYAML,
+	".json": RulesFormatJSON,
+}
+
+// semgrepRulesKey matches the top-level "rules" key of a Semgrep-style bundle
+var semgrepRulesKey = regexp.MustCompile(`(?m)^rules\s*:|"rules"\s*:`)
+
+// RulesUploadOptions configures UploadRules
+type RulesUploadOptions struct {
+	OrganizationID string
+
+	// Size of the file, used as the total for progress reports. When zero it
+	// is detected for files and in-memory readers.
+	Size int64
+
+	// OnProgress is called as the file is sent with the bytes sent so far and
+	// the total size, or -1 if it is unknown
+	OnProgress func(sent, total int64)
+}
+
+// UploadRules streams a YAML, JSON or Semgrep-style rules file to the API as
+// multipart/form-data. The file is validated before anything is sent. If the
+// request has to be repeated, file must implement io.Seeker.
+func (c *ScanRulesClient) UploadRules(file io.Reader, filename string, opts *RulesUploadOptions) (interface{}, error) {
+	return c.UploadRulesWithContext(context.Background(), file, filename, opts)
+}
+
+// UploadRulesWithContext streams a YAML, JSON or Semgrep-style rules file to the
+// API as multipart/form-data. The file is validated before anything is sent. If
+// the request has to be repeated, file must implement io.Seeker.
+func (c *ScanRulesClient) UploadRulesWithContext(ctx context.Context, file io.Reader, filename string, opts *RulesUploadOptions) (interface{}, error) {
+	if opts == nil {
+		opts = &RulesUploadOptions{}
+	}
+	body, err := newRulesUpload(file, filename, optionalString(opts.OrganizationID), opts.OnProgress)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Size > 0 {
+		body.size = opts.Size
+	}
+	var result interface{}
+	err = c.client.do(ctx, "POST", "/rules/upload", nil, body, &result)
+	return result, err
+}
+
+// UploadRulesFile uploads the rules file at path
+func (c *ScanRulesClient) UploadRulesFile(path string, opts *RulesUploadOptions) (interface{}, error) {
+	return c.UploadRulesFileWithContext(context.Background(), path, opts)
+}
+
+// UploadRulesFileWithContext uploads the rules file at path
+func (c *ScanRulesClient) UploadRulesFileWithContext(ctx context.Context, path string, opts *RulesUploadOptions) (interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return c.UploadRulesWithContext(ctx, file, filepath.Base(path), opts)
+}
+
+// DetectRulesFormat validates the name and leading content of a rules file and
+// returns its format
+func DetectRulesFormat(filename string, head []byte) (RulesFormat, error) {
+	format, ok := rulesExtensions[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q must have a .yaml, .yml or .json extension", ErrInvalidRulesFile, filename)
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 {
+		return "", fmt.Errorf("%w: %q is empty", ErrInvalidRulesFile, filename)
+	}
+	if bytes.IndexByte(head, 0) >= 0 || !utf8.Valid(trimIncompleteRune(head)) {
+		return "", fmt.Errorf("%w: %q is not a text file", ErrInvalidRulesFile, filename)
+	}
+	if format == RulesFormatJSON && trimmed[0] != '{' && trimmed[0] != '[' {
+		return "", fmt.Errorf("%w: %q does not contain a JSON object or array", ErrInvalidRulesFile, filename)
+	}
+
+	if semgrepRulesKey.Match(trimmed) {
+		return RulesFormatSemgrep, nil
+	}
+	return format, nil
+}
+
+// trimIncompleteRune drops a UTF-8 sequence cut off at the end of a partial read
+func trimIncompleteRune(data []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return data[:len(data)-i]
+			}
+			break
+		}
+	}
+	return data
+}
+
+// rulesContentType returns the MIME type sent for a rules file
+func rulesContentType(filename string) string {
+	if rulesExtensions[strings.ToLower(filepath.Ext(filename))] == RulesFormatJSON {
+		return "application/json"
+	}
+	return "application/yaml"
+}
+
+// rulesUpload is a validated rules file ready to be streamed as a multipart form
+type rulesUpload struct {
+	source         io.Reader
+	reader         *bufio.Reader
+	start          int64
+	opened         bool
+	done           chan struct{}
+	filename       string
+	boundary       string
+	organizationID *string
+	size           int64
+	onProgress     func(sent, total int64)
+}
+
+// newRulesUpload validates a rules file and prepares it for upload
+func newRulesUpload(file io.Reader, filename string, organizationID *string, onProgress func(sent, total int64)) (*rulesUpload, error) {
+	if file == nil {
+		return nil, fmt.Errorf("%w: no file given", ErrInvalidRulesFile)
+	}
+	filename = filepath.Base(filename)
+
+	upload := &rulesUpload{
+		source:         file,
+		reader:         bufio.NewReaderSize(file, rulesSniffLength),
+		start:          -1,
+		filename:       filename,
+		boundary:       multipart.NewWriter(io.Discard).Boundary(),
+		organizationID: organizationID,
+		size:           readerSize(file),
+		onProgress:     onProgress,
+	}
+	if seeker, ok := file.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			upload.start = offset
+		}
+	}
+
+	head, err := upload.reader.Peek(rulesSniffLength)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if _, err := DetectRulesFormat(filename, head); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// readerSize returns the remaining length of r, or -1 if it cannot be known without reading
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	}
+	return -1
+}
+
+// contentType implements streamBody
+func (u *rulesUpload) contentType() string {
+	return "multipart/form-data; boundary=" + u.boundary
+}
+
+// open implements streamBody. Each call streams a fresh multipart form.
+func (u *rulesUpload) open() (io.ReadCloser, error) {
+	file, err := u.rewind()
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	u.done = make(chan struct{})
+	go u.writeForm(pw, file, u.done)
+	return pr, nil
+}
+
+// rewind returns a reader positioned at the start of the file
+func (u *rulesUpload) rewind() (io.Reader, error) {
+	if !u.opened {
+		u.opened = true
+		return u.reader, nil
+	}
+	seeker, ok := u.source.(io.Seeker)
+	if !ok || u.start < 0 {
+		return nil, errors.New("tavo: rules upload cannot be resent because the file is not seekable")
+	}
+	// The previous attempt's body has been closed; wait until it stops reading the file
+	<-u.done
+	if _, err := seeker.Seek(u.start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return u.source, nil
+}
+
+// writeForm streams the multipart form into pw
+func (u *rulesUpload) writeForm(pw *io.PipeWriter, file io.Reader, done chan<- struct{}) {
+	defer close(done)
+	form := multipart.NewWriter(pw)
+	err := form.SetBoundary(u.boundary)
+	if err == nil && u.organizationID != nil {
+		err = form.WriteField("organization_id", *u.organizationID)
+	}
+	if err == nil {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", multipart.FileContentDisposition("file", u.filename))
+		header.Set("Content-Type", rulesContentType(u.filename))
+		var part io.Writer
+		if part, err = form.CreatePart(header); err == nil {
+			_, err = io.Copy(part, &progressReader{reader: file, total: u.size, onProgress: u.onProgress})
+		}
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	pw.CloseWithError(err)
+}
+
+// progressReader reports how much of the underlying reader has been consumed
+type progressReader struct {
+	reader     io.Reader
+	sent       int64
+	total      int64
+	onProgress func(sent, total int64)
+}
+
+// Read implements io.Reader
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.onProgress != nil {
+		r.sent += int64(n)
+		r.onProgress(r.sent, r.total)
+	}
+	return n, err
+}
@@ -0,0 +1,313 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testRules = "rules:\n  - id: no-eval\n    pattern: eval(...)\n    severity: ERROR\n"
+
+// uploadPart is one part of a received multipart form
+type uploadPart struct {
+	name        string
+	filename    string
+	contentType string
+	content     string
+}
+
+// uploadServer parses every upload with mime/multipart and answers with
+// statuses in order, then 200
+type uploadServer struct {
+	statuses []int
+
+	mu       sync.Mutex
+	requests [][]uploadPart
+	errs     []error
+}
+
+func (s *uploadServer) serve(w http.ResponseWriter, r *http.Request) {
+	parts, err := readUpload(r)
+	s.mu.Lock()
+	s.requests = append(s.requests, parts)
+	if err != nil {
+		s.errs = append(s.errs, err)
+	}
+	n := len(s.requests)
+	s.mu.Unlock()
+
+	if n <= len(s.statuses) {
+		w.WriteHeader(s.statuses[n-1])
+		w.Write([]byte(`{"error": "unavailable"}`))
+		return
+	}
+	w.Write([]byte(`{"rules_created": 1}`))
+}
+
+// readUpload reads the parts of a multipart/form-data request
+func readUpload(r *http.Request) ([]uploadPart, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, errors.New("request is not multipart/form-data")
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	var parts []uploadPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return parts, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return parts, err
+		}
+		parts = append(parts, uploadPart{
+			name:        part.FormName(),
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			content:     string(content),
+		})
+	}
+}
+
+// received returns the parts of every request and the errors parsing them
+func (s *uploadServer) received() ([][]uploadPart, []error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, s.errs
+}
+
+// progressLog records OnProgress calls, which come from the goroutine writing the form
+type progressLog struct {
+	mu    sync.Mutex
+	calls [][2]int64
+}
+
+func (l *progressLog) record(sent, total int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, [2]int64{sent, total})
+}
+
+func (l *progressLog) last() [2]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.calls) == 0 {
+		return [2]int64{}
+	}
+	return l.calls[len(l.calls)-1]
+}
+
+// readerOnly hides every method of a reader except Read
+type readerOnly struct {
+	io.Reader
+}
+
+func TestUploadRules(t *testing.T) {
+	server := &uploadServer{}
+	client := newTestClient(t, server.serve)
+	progress := &progressLog{}
+
+	result, err := client.ScanRules().UploadRules(strings.NewReader(testRules), "configs/rules.yaml", &RulesUploadOptions{
+		OrganizationID: "org-1",
+		OnProgress:     progress.record,
+	})
+	if err != nil {
+		t.Fatalf("UploadRules: %v", err)
+	}
+	if response, ok := result.(map[string]interface{}); !ok || response["rules_created"] != float64(1) {
+		t.Errorf("result = %v", result)
+	}
+
+	requests, errs := server.received()
+	if len(requests) != 1 || len(errs) != 0 {
+		t.Fatalf("requests = %+v, errors = %v", requests, errs)
+	}
+	want := []uploadPart{
+		{name: "organization_id", content: "org-1"},
+		{name: "file", filename: "rules.yaml", contentType: "application/yaml", content: testRules},
+	}
+	if len(requests[0]) != len(want) {
+		t.Fatalf("parts = %+v, want %+v", requests[0], want)
+	}
+	for i := range want {
+		if requests[0][i] != want[i] {
+			t.Errorf("part %d = %+v, want %+v", i, requests[0][i], want[i])
+		}
+	}
+
+	total := int64(len(testRules))
+	if got := progress.last(); got != [2]int64{total, total} {
+		t.Errorf("last progress = %v, want %d of %d", got, total, total)
+	}
+	for i, call := range progress.calls {
+		if call[1] != total || i > 0 && call[0] < progress.calls[i-1][0] {
+			t.Errorf("progress = %v, want a growing count of %d", progress.calls, total)
+			break
+		}
+	}
+}
+
+func TestUploadRulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	content := `{"rules": [{"id": "no-eval"}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := &uploadServer{}
+	client := newTestClient(t, server.serve)
+	progress := &progressLog{}
+
+	if _, err := client.ScanRules().UploadRulesFile(path, &RulesUploadOptions{OnProgress: progress.record}); err != nil {
+		t.Fatalf("UploadRulesFile: %v", err)
+	}
+	requests, _ := server.received()
+	want := uploadPart{name: "file", filename: "rules.json", contentType: "application/json", content: content}
+	if len(requests) != 1 || len(requests[0]) != 1 || requests[0][0] != want {
+		t.Errorf("requests = %+v, want one part %+v", requests, want)
+	}
+	// The size of a file is known from Stat
+	if got := progress.last(); got != [2]int64{int64(len(content)), int64(len(content))} {
+		t.Errorf("last progress = %v", got)
+	}
+}
+
+func TestUploadRulesProgressTotal(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int64
+		wantTotal int64
+	}{
+		{"unknown", 0, -1},
+		{"from options", 500, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &uploadServer{}
+			client := newTestClient(t, server.serve)
+			progress := &progressLog{}
+			opts := &RulesUploadOptions{Size: tt.size, OnProgress: progress.record}
+			if _, err := client.ScanRules().UploadRules(readerOnly{strings.NewReader(testRules)}, "rules.yml", opts); err != nil {
+				t.Fatal(err)
+			}
+			if got := progress.last(); got != [2]int64{int64(len(testRules)), tt.wantTotal} {
+				t.Errorf("last progress = %v, want total %d", got, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestUploadRulesValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     io.Reader
+		filename string
+		wantErr  string
+	}{
+		{"no file", nil, "rules.yaml", "no file given"},
+		{"extension", strings.NewReader(testRules), "rules.txt", "extension"},
+		{"no extension", strings.NewReader(testRules), "rules", "extension"},
+		{"empty", strings.NewReader(" \n\t"), "rules.yaml", "is empty"},
+		{"binary", strings.NewReader("rules:\x00\x01"), "rules.yaml", "not a text file"},
+		{"invalid utf-8", strings.NewReader("rules: \xff\xfe"), "rules.yaml", "not a text file"},
+		{"json scalar", strings.NewReader(`"rules"`), "rules.json", "JSON object or array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &uploadServer{}
+			client := newTestClient(t, server.serve)
+			_, err := client.ScanRules().UploadRules(tt.file, tt.filename, nil)
+			if !errors.Is(err, ErrInvalidRulesFile) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want ErrInvalidRulesFile containing %q", err, tt.wantErr)
+			}
+			if requests, _ := server.received(); len(requests) != 0 {
+				t.Errorf("sent %d requests for an invalid file", len(requests))
+			}
+		})
+	}
+}
+
+func TestDetectRulesFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		head     string
+		want     RulesFormat
+	}{
+		{"rules.yaml", "- id: a\n", RulesFormatYAML},
+		{"RULES.YML", "\xef\xbb\xbfkey: value\n", RulesFormatYAML},
+		{"rules.json", " [{\"id\": \"a\"}]", RulesFormatJSON},
+		{"rules.yaml", testRules, RulesFormatSemgrep},
+		{"rules.json", `{"rules": []}`, RulesFormatSemgrep},
+		{"rules.yaml", "caf\xc3", RulesFormatYAML},
+	}
+	for _, tt := range tests {
+		if got, err := DetectRulesFormat(tt.filename, []byte(tt.head)); err != nil || got != tt.want {
+			t.Errorf("DetectRulesFormat(%q, %q) = %q, %v; want %q", tt.filename, tt.head, got, err, tt.want)
+		}
+	}
+}
+
+func TestUploadRulesResend(t *testing.T) {
+	server := &uploadServer{statuses: []int{http.StatusServiceUnavailable}}
+	client := newTestClient(t, server.serve)
+	progress := &progressLog{}
+
+	// The reader is not at offset 0; each attempt starts where the upload began
+	reader := strings.NewReader("# header skipped by the caller\n" + testRules)
+	reader.Seek(int64(len("# header skipped by the caller\n")), io.SeekStart)
+
+	ctx := WithIdempotencyKey(context.Background(), "upload-1")
+	if _, err := client.ScanRules().UploadRulesWithContext(ctx, reader, "rules.yaml", &RulesUploadOptions{OnProgress: progress.record}); err != nil {
+		t.Fatalf("UploadRules: %v", err)
+	}
+
+	requests, errs := server.received()
+	if len(requests) != 2 || len(errs) != 0 {
+		t.Fatalf("requests = %+v, errors = %v; want two complete forms", requests, errs)
+	}
+	for i, parts := range requests {
+		if len(parts) != 1 || parts[0].content != testRules {
+			t.Errorf("attempt %d sent %+v, want the file from its starting offset", i+1, parts)
+		}
+	}
+	// Progress starts over for the second attempt and reaches the total again
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+	total := int64(len(testRules))
+	complete := 0
+	for _, call := range progress.calls {
+		if call == [2]int64{total, total} {
+			complete++
+		}
+	}
+	if complete != 2 {
+		t.Errorf("progress = %v, want each attempt to report %d of %d", progress.calls, total, total)
+	}
+}
+
+func TestUploadRulesCannotResendUnseekable(t *testing.T) {
+	server := &uploadServer{statuses: []int{http.StatusServiceUnavailable}}
+	client := newTestClient(t, server.serve)
+
+	ctx := WithIdempotencyKey(context.Background(), "upload-1")
+	_, err := client.ScanRules().UploadRulesWithContext(ctx, readerOnly{strings.NewReader(testRules)}, "rules.yaml", nil)
+	if err == nil || !strings.Contains(err.Error(), "not seekable") {
+		t.Errorf("err = %v, want an error saying the file is not seekable", err)
+	}
+	if requests, _ := server.received(); len(requests) != 1 {
+		t.Errorf("sent %d requests, want 1", len(requests))
+	}
+}
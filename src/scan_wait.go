@@ -0,0 +1,259 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScanOutcome is how waiting for a scan ended
+type ScanOutcome string
+
+const (
+	ScanOutcomeCompleted ScanOutcome = "completed"
+	ScanOutcomeFailed    ScanOutcome = "failed"
+	ScanOutcomeCancelled ScanOutcome = "cancelled"
+	ScanOutcomeTimedOut  ScanOutcome = "timed_out"
+
+	// ScanOutcomeStopped means the wait ended before the scan did because ctx
+	// was cancelled or the scan could not be fetched
+	ScanOutcomeStopped ScanOutcome = "stopped"
+)
+
+var (
+	// ErrScanFailed is returned by WaitForScan when the scan ends in the failed state
+	ErrScanFailed = errors.New("tavo: scan failed")
+
+	// ErrScanCancelled is returned by WaitForScan when the scan was cancelled
+	ErrScanCancelled = errors.New("tavo: scan was cancelled")
+
+	// ErrScanTimedOut is returned by WaitForScan when the scan did not finish in time
+	ErrScanTimedOut = errors.New("tavo: timed out waiting for scan")
+)
+
+// WaitOptions configures WaitForScan
+type WaitOptions struct {
+	// Maximum time to wait; zero waits until ctx is done
+	Timeout time.Duration
+
+	// Poll interval bounds. The interval starts at MinInterval, grows by
+	// Multiplier while the scan makes no progress and resets when it does.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+
+	// Listen on the live event stream and poll only as a fallback. If the
+	// stream cannot be opened, WaitForScan silently falls back to polling.
+	UseEvents bool
+
+	// OnProgress is called whenever the scan's status or progress changes
+	OnProgress func(progress *ScanProgressEvent)
+
+	// Skip fetching the findings of a completed scan
+	SkipResults bool
+}
+
+// DefaultWaitOptions returns the options used when WaitForScan is given nil
+func DefaultWaitOptions() *WaitOptions {
+	return &WaitOptions{
+		MinInterval: time.Second,
+		MaxInterval: 30 * time.Second,
+		Multiplier:  1.5,
+	}
+}
+
+// ScanWaitResult is the final state of a scan returned by WaitForScan
+type ScanWaitResult struct {
+	Outcome ScanOutcome
+
+	// Last known state of the scan; nil if it was never fetched
+	Scan *Scan
+
+	// Findings and severity counts of a completed scan, unless SkipResults was set
+	Results *ScanResult
+	Summary *ScanSummary
+}
+
+// WaitForScan blocks until the scan reaches a terminal state, the timeout
+// elapses or ctx is done. The result is returned for every outcome; the error
+// is nil only for a completed scan and otherwise wraps ErrScanFailed,
+// ErrScanCancelled, ErrScanTimedOut or the error that stopped the wait.
+func (c *ScansClient) WaitForScan(ctx context.Context, scanID string, opts *WaitOptions) (*ScanWaitResult, error) {
+	options := DefaultWaitOptions()
+	if opts != nil {
+		copied := *opts
+		options = &copied
+	}
+	defaults := DefaultWaitOptions()
+	if options.MinInterval <= 0 {
+		options.MinInterval = defaults.MinInterval
+	}
+	if options.MaxInterval < options.MinInterval {
+		options.MaxInterval = max(defaults.MaxInterval, options.MinInterval)
+	}
+	if options.Multiplier < 1 {
+		options.Multiplier = defaults.Multiplier
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, options.Timeout, ErrScanTimedOut)
+		defer cancel()
+	}
+
+	var events <-chan Event
+	if options.UseEvents {
+		if stream, err := c.client.websockets.Connect(ctx, nil); err == nil {
+			defer stream.Close()
+			if stream.SubscribeScan(scanID) == nil {
+				events = stream.Events()
+			}
+		}
+	}
+
+	waiter := &scanWaiter{options: options}
+	interval := options.MinInterval
+	for {
+		scan, err := c.GetScanWithContext(ctx, scanID)
+		switch {
+		case err == nil:
+			waiter.scan = scan
+			if waiter.report(&ScanProgressEvent{ScanID: scanID, Status: scan.Status, Progress: scan.Progress, FindingsCount: scan.TotalFindings}) {
+				interval = options.MinInterval
+			} else {
+				interval = min(time.Duration(float64(interval)*options.Multiplier), options.MaxInterval)
+			}
+			if scan.Status.IsTerminal() {
+				return c.finishWait(ctx, waiter)
+			}
+		case ctx.Err() != nil:
+			return waiter.stopped(ctx)
+		case IsRateLimited(err) || IsServerError(err):
+			// Keep waiting through transient failures that outlived the client's retries
+			if apiErr, ok := AsAPIError(err); ok && apiErr.RetryAfter > interval {
+				interval = apiErr.RetryAfter
+			}
+		default:
+			return waiter.result(ScanOutcomeStopped), err
+		}
+
+		if err := waiter.sleep(ctx, interval, scanID, &events); err != nil {
+			return waiter.stopped(ctx)
+		}
+	}
+}
+
+// finishWait builds the result for a scan in a terminal state
+func (c *ScansClient) finishWait(ctx context.Context, waiter *scanWaiter) (*ScanWaitResult, error) {
+	scan := waiter.scan
+	switch scan.Status {
+	case ScanStatusFailed:
+		if scan.ErrorMessage != "" {
+			return waiter.result(ScanOutcomeFailed), fmt.Errorf("%w: %s", ErrScanFailed, scan.ErrorMessage)
+		}
+		return waiter.result(ScanOutcomeFailed), ErrScanFailed
+	case ScanStatusCancelled:
+		return waiter.result(ScanOutcomeCancelled), ErrScanCancelled
+	}
+
+	result := waiter.result(ScanOutcomeCompleted)
+	if waiter.options.SkipResults {
+		return result, nil
+	}
+	results, err := c.GetScanResultsWithContext(ctx, scan.ID, nil)
+	if err != nil {
+		return result, fmt.Errorf("fetch scan results: %w", err)
+	}
+	result.Results = results
+	result.Summary = results.Summary
+	if result.Summary == nil {
+		result.Summary = summarizeFindings(results.Findings)
+	}
+	return result, nil
+}
+
+// scanWaiter tracks the state of a single WaitForScan call
+type scanWaiter struct {
+	options  *WaitOptions
+	scan     *Scan
+	reported *ScanProgressEvent
+}
+
+// report passes progress to OnProgress if it differs from the last report and
+// says whether it did
+func (w *scanWaiter) report(progress *ScanProgressEvent) bool {
+	if w.reported != nil && w.reported.Status == progress.Status && w.reported.Progress == progress.Progress {
+		return false
+	}
+	w.reported = progress
+	if w.options.OnProgress != nil {
+		w.options.OnProgress(progress)
+	}
+	return true
+}
+
+// sleep waits for interval, returning early when an event reports that the scan
+// finished. A closed event stream is dropped and polling continues.
+func (w *scanWaiter) sleep(ctx context.Context, interval time.Duration, scanID string, events *<-chan Event) error {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case event, ok := <-*events:
+			if !ok {
+				*events = nil
+				continue
+			}
+			progress, isScan := event.Payload.(*ScanProgressEvent)
+			if !isScan || progress.ScanID != scanID {
+				continue
+			}
+			w.report(progress)
+			if event.Type == EventScanCompleted || progress.Status.IsTerminal() {
+				return nil
+			}
+		}
+	}
+}
+
+// stopped builds the result for a wait ended by ctx
+func (w *scanWaiter) stopped(ctx context.Context) (*ScanWaitResult, error) {
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, ErrScanTimedOut):
+		return w.result(ScanOutcomeTimedOut), cause
+	case errors.Is(cause, context.DeadlineExceeded):
+		return w.result(ScanOutcomeTimedOut), fmt.Errorf("%w: %w", ErrScanTimedOut, cause)
+	}
+	return w.result(ScanOutcomeStopped), cause
+}
+
+// result returns a ScanWaitResult holding the last known scan
+func (w *scanWaiter) result(outcome ScanOutcome) *ScanWaitResult {
+	return &ScanWaitResult{Outcome: outcome, Scan: w.scan}
+}
+
+// summarizeFindings counts findings by severity
+func summarizeFindings(findings []Finding) *ScanSummary {
+	summary := &ScanSummary{Total: len(findings)}
+	for _, finding := range findings {
+		switch finding.Severity {
+		case SeverityCritical:
+			summary.Critical++
+		case SeverityHigh:
+			summary.High++
+		case SeverityMedium:
+			summary.Medium++
+		case SeverityLow:
+			summary.Low++
+		case SeverityInfo:
+			summary.Info++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,288 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const waitScanID = "3f2b8c1e-4d5a-4b6c-9e7f-0a1b2c3d4e5f"
+
+// scanServer answers scan fetches with states in order, repeating the last one,
+// and serves two findings as the scan's results
+type scanServer struct {
+	states []string
+
+	mu    sync.Mutex
+	polls []time.Time
+}
+
+func (s *scanServer) serve(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/results") {
+		w.Write([]byte(`{"results": [{"rule_id": "r1", "severity": "high"}, {"rule_id": "r2", "severity": "low"}]}`))
+		return
+	}
+
+	s.mu.Lock()
+	s.polls = append(s.polls, time.Now())
+	state := s.states[min(len(s.polls), len(s.states))-1]
+	s.mu.Unlock()
+
+	if status, ok := strings.CutPrefix(state, "HTTP "); ok {
+		var code int
+		fmt.Sscan(status, &code)
+		w.WriteHeader(code)
+		w.Write([]byte(`{"error": "unavailable"}`))
+		return
+	}
+	fmt.Fprintf(w, `{"id": %q, %s}`, waitScanID, state)
+}
+
+// pollTimes returns when each scan fetch arrived
+func (s *scanServer) pollTimes() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.polls...)
+}
+
+// fastWait polls every millisecond
+func fastWait() *WaitOptions {
+	return &WaitOptions{MinInterval: time.Millisecond, MaxInterval: time.Millisecond}
+}
+
+func TestWaitForScanOutcomes(t *testing.T) {
+	running := `"status": "running", "progress": 40`
+	tests := []struct {
+		name        string
+		states      []string
+		opts        *WaitOptions
+		wantOutcome ScanOutcome
+		wantErr     error
+		wantStatus  ScanStatus
+	}{
+		{
+			name:        "completed",
+			states:      []string{`"status": "pending"`, running, `"status": "completed", "progress": 100`},
+			wantOutcome: ScanOutcomeCompleted,
+			wantStatus:  ScanStatusCompleted,
+		},
+		{
+			name:        "completed through transient errors",
+			states:      []string{running, "HTTP 503", "HTTP 429", `"status": "completed"`},
+			wantOutcome: ScanOutcomeCompleted,
+			wantStatus:  ScanStatusCompleted,
+		},
+		{
+			name:        "failed",
+			states:      []string{running, `"status": "failed", "error_message": "clone failed"`},
+			wantOutcome: ScanOutcomeFailed,
+			wantErr:     ErrScanFailed,
+			wantStatus:  ScanStatusFailed,
+		},
+		{
+			name:        "cancelled",
+			states:      []string{`"status": "cancelled"`},
+			wantOutcome: ScanOutcomeCancelled,
+			wantErr:     ErrScanCancelled,
+			wantStatus:  ScanStatusCancelled,
+		},
+		{
+			name:        "timed out",
+			states:      []string{running},
+			opts:        &WaitOptions{Timeout: 50 * time.Millisecond, MinInterval: time.Millisecond},
+			wantOutcome: ScanOutcomeTimedOut,
+			wantErr:     ErrScanTimedOut,
+			wantStatus:  ScanStatusRunning,
+		},
+		{
+			name:        "not found",
+			states:      []string{"HTTP 404"},
+			wantOutcome: ScanOutcomeStopped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &scanServer{states: tt.states}
+			client := newTestClient(t, server.serve, WithRetryPolicy(NoRetries()))
+			opts := tt.opts
+			if opts == nil {
+				opts = fastWait()
+			}
+
+			result, err := client.Scans().WaitForScan(context.Background(), waitScanID, opts)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantOutcome == ScanOutcomeStopped:
+				if !IsNotFound(err) {
+					t.Fatalf("err = %v, want the 404", err)
+				}
+			case err != nil:
+				t.Fatalf("WaitForScan: %v", err)
+			}
+
+			if result.Outcome != tt.wantOutcome {
+				t.Errorf("Outcome = %q, want %q", result.Outcome, tt.wantOutcome)
+			}
+			if tt.wantStatus == "" {
+				if result.Scan != nil {
+					t.Errorf("Scan = %+v, want nil", result.Scan)
+				}
+			} else if result.Scan == nil || result.Scan.Status != tt.wantStatus {
+				t.Errorf("Scan = %+v, want status %q", result.Scan, tt.wantStatus)
+			}
+			if tt.wantOutcome == ScanOutcomeCompleted {
+				if result.Results == nil || len(result.Results.Findings) != 2 || result.Summary == nil || *result.Summary != (ScanSummary{Total: 2, High: 1, Low: 1}) {
+					t.Errorf("Results, Summary = %+v, %+v", result.Results, result.Summary)
+				}
+			} else if result.Results != nil {
+				t.Errorf("Results = %+v for a %s scan", result.Results, tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestWaitForScanFailureMessage(t *testing.T) {
+	server := &scanServer{states: []string{`"status": "failed", "error_message": "clone failed"`}}
+	client := newTestClient(t, server.serve)
+	if _, err := client.Scans().WaitForScan(context.Background(), waitScanID, fastWait()); err == nil || !strings.Contains(err.Error(), "clone failed") {
+		t.Errorf("err = %v, want the scan's error message", err)
+	}
+}
+
+func TestWaitForScanContext(t *testing.T) {
+	server := &scanServer{states: []string{`"status": "running"`}}
+	client := newTestClient(t, server.serve)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	result, err := client.Scans().WaitForScan(ctx, waitScanID, fastWait())
+	if !errors.Is(err, context.Canceled) || result.Outcome != ScanOutcomeStopped || result.Scan == nil {
+		t.Errorf("WaitForScan after cancel = %+v, %v; want ScanOutcomeStopped and context.Canceled", result, err)
+	}
+
+	deadline, stop := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer stop()
+	result, err = client.Scans().WaitForScan(deadline, waitScanID, fastWait())
+	if !errors.Is(err, ErrScanTimedOut) || !errors.Is(err, context.DeadlineExceeded) || result.Outcome != ScanOutcomeTimedOut {
+		t.Errorf("WaitForScan past the ctx deadline = %+v, %v; want ScanOutcomeTimedOut", result, err)
+	}
+}
+
+func TestWaitForScanBackoff(t *testing.T) {
+	stalled := `"status": "running", "progress": 10`
+	server := &scanServer{states: []string{stalled, stalled, stalled, stalled, stalled, `"status": "running", "progress": 60`, `"status": "completed"`}}
+	client := newTestClient(t, server.serve)
+
+	var reported []float64
+	opts := &WaitOptions{
+		MinInterval: 20 * time.Millisecond,
+		MaxInterval: 80 * time.Millisecond,
+		Multiplier:  2,
+		SkipResults: true,
+		OnProgress:  func(p *ScanProgressEvent) { reported = append(reported, p.Progress) },
+	}
+	if _, err := client.Scans().WaitForScan(context.Background(), waitScanID, opts); err != nil {
+		t.Fatalf("WaitForScan: %v", err)
+	}
+
+	// The interval doubles up to MaxInterval while progress stalls and resets once it moves
+	polls := server.pollTimes()
+	wantGaps := []time.Duration{20, 40, 80, 80, 80, 20}
+	if len(polls) != len(wantGaps)+1 {
+		t.Fatalf("polled %d times, want %d", len(polls), len(wantGaps)+1)
+	}
+	for i, want := range wantGaps {
+		if gap := polls[i+1].Sub(polls[i]); gap < want*time.Millisecond {
+			t.Errorf("gap before poll %d = %v, want at least %v", i+1, gap, want*time.Millisecond)
+		}
+	}
+	if gap := polls[6].Sub(polls[5]); gap >= 80*time.Millisecond {
+		t.Errorf("gap after progress = %v, want the interval reset to MinInterval", gap)
+	}
+	if want := []float64{10, 60, 0}; fmt.Sprint(reported) != fmt.Sprint(want) {
+		t.Errorf("reported progress = %v, want %v", reported, want)
+	}
+}
+
+func TestWaitForScanEvents(t *testing.T) {
+	scans := &scanServer{states: []string{`"status": "running", "progress": 5`, `"status": "completed", "progress": 100`}}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ws" {
+			scans.serve(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var sub subscriptionMessage
+		if conn.ReadJSON(&sub) != nil {
+			return
+		}
+		for _, event := range []string{
+			`{"type": "scan.progress", "channel": "scan:other", "data": {"scan_id": "other", "status": "completed"}}`,
+			fmt.Sprintf(`{"type": "scan.progress", "channel": %q, "data": {"scan_id": %q, "status": "running", "progress": 50}}`, sub.Channel, waitScanID),
+			fmt.Sprintf(`{"type": "scan.completed", "channel": %q, "data": {"scan_id": %q, "status": "completed", "progress": 100}}`, sub.Channel, waitScanID),
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		waitClosed(conn)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("test-key", "", server.URL, WithRetryPolicy(fastRetries()))
+
+	var mu sync.Mutex
+	var reported []float64
+	opts := &WaitOptions{
+		MinInterval: time.Minute,
+		UseEvents:   true,
+		SkipResults: true,
+		OnProgress: func(p *ScanProgressEvent) {
+			mu.Lock()
+			reported = append(reported, p.Progress)
+			mu.Unlock()
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The completion event ends the minute-long sleep and the scan is fetched again
+	result, err := client.Scans().WaitForScan(ctx, waitScanID, opts)
+	if err != nil || result.Outcome != ScanOutcomeCompleted {
+		t.Fatalf("WaitForScan = %+v, %v", result, err)
+	}
+	if polls := len(scans.pollTimes()); polls != 2 {
+		t.Errorf("polled %d times, want 2", polls)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []float64{5, 50, 100}; fmt.Sprint(reported) != fmt.Sprint(want) {
+		t.Errorf("reported progress = %v, want %v", reported, want)
+	}
+}
+
+func TestWaitForScanWithoutEventStream(t *testing.T) {
+	server := &scanServer{states: []string{`"status": "running"`, `"status": "completed"`}}
+	client := newTestClient(t, server.serve)
+
+	// The event stream endpoint is not served, so waiting falls back to polling
+	opts := fastWait()
+	opts.UseEvents = true
+	opts.SkipResults = true
+	if result, err := client.Scans().WaitForScan(context.Background(), waitScanID, opts); err != nil || result.Outcome != ScanOutcomeCompleted {
+		t.Errorf("WaitForScan = %+v, %v", result, err)
+	}
+}
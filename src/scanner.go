@@ -2,15 +2,43 @@
 package scanner
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// ErrScannerTimeout is returned when tavo-scanner runs past its timeout or the context deadline
+var ErrScannerTimeout = errors.New("tavo-scanner timed out")
+
+// ErrScannerCancelled is returned when the context is cancelled while tavo-scanner is running
+var ErrScannerCancelled = errors.New("tavo-scanner cancelled")
+
+// ErrInvalidScanOptions is returned when ScanOptions contain conflicting or malformed settings
+var ErrInvalidScanOptions = errors.New("invalid scan options")
+
+// ErrScannerFailed is returned when tavo-scanner crashes or exits with an error code
+var ErrScannerFailed = errors.New("tavo-scanner failed")
+
+// ExitCodeFindings is the exit code tavo-scanner uses when a scan succeeded
+// and reported findings. Any other non-zero code means the scanner failed.
+const ExitCodeFindings = 1
+
+// scannerWaitDelay bounds how long to wait for output after the scanner has been killed
+const scannerWaitDelay = 5 * time.Second
+
 // ScannerConfig represents configuration for tavo-scanner execution
 type ScannerConfig struct {
 	// Path to tavo-scanner binary
@@ -19,6 +47,9 @@ type ScannerConfig struct {
 	// List of plugins to use
 	Plugins []string
 
+	// Directory the scanner loads plugins from, e.g. a PluginCache's PluginDir
+	PluginDir string
+
 	// Plugin-specific configuration
 	PluginConfig map[string]interface{}
 
@@ -73,21 +104,36 @@ func findScannerBinary() string {
 	return ""
 }
 
-// ScanOptions represents options for scanner execution
+// ScanOptions represents options for scanner execution. They are merged with
+// the scanner's ScannerConfig for a single call:
+//
+//   - StaticPlugins extend the configured plugins, unless ReplacePlugins is set.
+//   - StaticRules takes the place of the configured rules file; ReplaceRules
+//     also drops the configured rules file and custom rules when it is empty.
+//   - OutputFormat, OutputFile and Timeout override the configuration when
+//     they are set; a negative Timeout disables the timeout.
+//   - StaticAnalysis only turns static analysis off when DynamicTesting is on,
+//     so options that enable neither still run static analysis.
 type ScanOptions struct {
-	// Static analysis enabled
+	// Static analysis enabled; turning it off together with DynamicTesting passes --no-static
 	StaticAnalysis bool
 
-	// Static analysis plugins
+	// Static analysis plugins, passed as --plugin
 	StaticPlugins []string
 
+	// Use StaticPlugins instead of adding them to the configured plugins
+	ReplacePlugins bool
+
 	// Custom rules path
 	StaticRules string
 
-	// Dynamic testing enabled
+	// Use StaticRules instead of the configured rules, even when it is empty
+	ReplaceRules bool
+
+	// Dynamic testing enabled, passed as --dynamic
 	DynamicTesting bool
 
-	// Dynamic testing plugins
+	// Dynamic testing plugins, each passed as --dynamic-plugin
 	DynamicPlugins []string
 
 	// Output format
@@ -96,22 +142,21 @@ type ScanOptions struct {
 	// Output file path
 	OutputFile string
 
-	// Execution timeout
+	// Execution timeout in seconds
 	Timeout int
 
-	// Files to exclude
+	// Glob patterns of files to exclude, each passed as --exclude
 	ExcludePatterns []string
 
-	// Files to include
+	// Glob patterns of files to include, each passed as --include
 	IncludePatterns []string
 }
 
-// NewScanOptions creates default scan options
+// NewScanOptions creates scan options that run static analysis. OutputFormat,
+// OutputFile and Timeout are left unset so the scanner's configuration applies.
 func NewScanOptions() *ScanOptions {
 	return &ScanOptions{
 		StaticAnalysis:  true,
-		OutputFormat:    "json",
-		Timeout:         300,
 		ExcludePatterns: []string{},
 		IncludePatterns: []string{},
 	}
@@ -122,16 +167,30 @@ type ScanResult struct {
 	// Execution status
 	Status string `json:"status"`
 
-	// Scan results
+	// Scan results as decoded from the scanner's JSON output
 	Results []interface{} `json:"results,omitempty"`
 
-	// Raw output
+	// Typed findings decoded from the same output
+	Findings []Finding `json:"findings,omitempty"`
+
+	// Raw standard output, set when it is not JSON findings or the scan was interrupted
 	Output string `json:"output,omitempty"`
 
+	// Standard error, which carries the scanner's logs and diagnostics
+	Stderr string `json:"stderr,omitempty"`
+
+	// Exit code of the scanner: 0 without findings, ExitCodeFindings with findings
+	ExitCode int `json:"exit_code"`
+
 	// Error message
 	Error string `json:"error,omitempty"`
 }
 
+// HasFindings reports whether the scanner reported any findings
+func (r *ScanResult) HasFindings() bool {
+	return len(r.Findings) > 0 || r.ExitCode == ExitCodeFindings
+}
+
 // TavoScanner wraps tavo-scanner execution
 type TavoScanner struct {
 	config *ScannerConfig
@@ -147,14 +206,39 @@ func NewTavoScanner(config *ScannerConfig) *TavoScanner {
 
 // ScanDirectory scans a directory with tavo-scanner
 func (s *TavoScanner) ScanDirectory(targetPath string, scanOptions *ScanOptions) (*ScanResult, error) {
+	return s.ScanDirectoryContext(context.Background(), targetPath, scanOptions)
+}
+
+// ScanDirectoryContext scans a directory with tavo-scanner. The scanner and any
+// processes it started are killed when ctx is done or the timeout expires; the
+// returned result then holds the output produced so far.
+func (s *TavoScanner) ScanDirectoryContext(ctx context.Context, targetPath string, scanOptions *ScanOptions) (*ScanResult, error) {
 	if s.config.ScannerPath == "" {
 		return nil, fmt.Errorf("tavo-scanner binary not found. Please install tavo-cli or set ScannerPath")
 	}
 
-	// Merge configurations
+	mergedConfig, err := s.mergeConfig(scanOptions)
+	if err != nil {
+		return nil, err
+	}
+	files, err := writeRunFiles(mergedConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Deferred so the files are removed on timeout and panic as well
+	defer files.remove()
+
+	args := scanArgs(targetPath, mergedConfig, scanOptions, files)
+	return s.executeScanner(ctx, args, mergedConfig)
+}
+
+// mergeConfig combines the scanner configuration with per-call options
+// following the policy documented on ScanOptions
+func (s *TavoScanner) mergeConfig(scanOptions *ScanOptions) (*ScannerConfig, error) {
 	mergedConfig := &ScannerConfig{
 		ScannerPath:      s.config.ScannerPath,
 		Plugins:          make([]string, len(s.config.Plugins)),
+		PluginDir:        s.config.PluginDir,
 		PluginConfig:     make(map[string]interface{}),
 		RulesPath:        s.config.RulesPath,
 		CustomRules:      make(map[string]interface{}),
@@ -171,27 +255,137 @@ func (s *TavoScanner) ScanDirectory(targetPath string, scanOptions *ScanOptions)
 		mergedConfig.CustomRules[k] = v
 	}
 
-	if scanOptions != nil {
-		mergedConfig.Plugins = scanOptions.StaticPlugins
+	if scanOptions == nil {
+		return mergedConfig, nil
+	}
+	if err := scanOptions.validate(); err != nil {
+		return nil, err
+	}
+
+	if scanOptions.ReplacePlugins {
+		mergedConfig.Plugins = []string{}
+	}
+	for _, plugin := range scanOptions.StaticPlugins {
+		if !containsString(mergedConfig.Plugins, plugin) {
+			mergedConfig.Plugins = append(mergedConfig.Plugins, plugin)
+		}
+	}
+
+	if scanOptions.ReplaceRules {
+		mergedConfig.RulesPath = ""
+		mergedConfig.CustomRules = make(map[string]interface{})
+	}
+	if scanOptions.StaticRules != "" {
 		mergedConfig.RulesPath = scanOptions.StaticRules
+	}
+
+	if scanOptions.Timeout != 0 {
 		mergedConfig.Timeout = scanOptions.Timeout
+	}
+	if scanOptions.OutputFormat != "" {
 		mergedConfig.OutputFormat = scanOptions.OutputFormat
+	}
+	if scanOptions.OutputFile != "" {
 		mergedConfig.OutputFile = scanOptions.OutputFile
 	}
 
+	return mergedConfig, nil
+}
+
+// staticDisabled reports whether the options turn static analysis off
+func (o *ScanOptions) staticDisabled() bool {
+	return !o.StaticAnalysis && o.DynamicTesting
+}
+
+// validate reports conflicting or malformed options
+func (o *ScanOptions) validate() error {
+	if len(o.DynamicPlugins) > 0 && !o.DynamicTesting {
+		return fmt.Errorf("%w: DynamicPlugins are set but DynamicTesting is disabled", ErrInvalidScanOptions)
+	}
+	if o.staticDisabled() && (len(o.StaticPlugins) > 0 || o.StaticRules != "") {
+		return fmt.Errorf("%w: StaticPlugins or StaticRules are set but StaticAnalysis is disabled", ErrInvalidScanOptions)
+	}
+	for _, patterns := range [][]string{o.IncludePatterns, o.ExcludePatterns} {
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%w: empty file pattern", ErrInvalidScanOptions)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%w: file pattern %q: %v", ErrInvalidScanOptions, pattern, err)
+			}
+		}
+	}
+	for _, pattern := range o.IncludePatterns {
+		if containsString(o.ExcludePatterns, pattern) {
+			return fmt.Errorf("%w: pattern %q is both included and excluded", ErrInvalidScanOptions, pattern)
+		}
+	}
+	return nil
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// scanArgs builds the tavo-scanner command line for a merged configuration,
+// the per-call options and the files written for the run:
+//
+//	<target> [--plugin-dir <dir>] [--plugin <name>]...
+//	         [--plugin-config <file>] [--rules <file>]
+//	         [--include <glob>]... [--exclude <glob>]...
+//	         [--no-static] [--dynamic [--dynamic-plugin <name>]...]
+//	         [--format <format>] [--output <file>] [--timeout <seconds>]
+func scanArgs(targetPath string, mergedConfig *ScannerConfig, scanOptions *ScanOptions, files *runFiles) []string {
 	// Prepare command arguments
 	args := []string{targetPath}
 
 	// Add plugins
+	if mergedConfig.PluginDir != "" {
+		args = append(args, "--plugin-dir", mergedConfig.PluginDir)
+	}
 	for _, plugin := range mergedConfig.Plugins {
 		args = append(args, "--plugin", plugin)
 	}
 
-	// Add rules
-	if mergedConfig.RulesPath != "" {
+	// Add plugin configuration
+	if files.pluginConfigFile != "" {
+		args = append(args, "--plugin-config", files.pluginConfigFile)
+	}
+
+	// Add rules, preferring the file that merges in the custom rules
+	if files.rulesFile != "" {
+		args = append(args, "--rules", files.rulesFile)
+	} else if mergedConfig.RulesPath != "" {
 		args = append(args, "--rules", mergedConfig.RulesPath)
 	}
 
+	if scanOptions != nil {
+		// Add file filters
+		for _, pattern := range scanOptions.IncludePatterns {
+			args = append(args, "--include", pattern)
+		}
+		for _, pattern := range scanOptions.ExcludePatterns {
+			args = append(args, "--exclude", pattern)
+		}
+
+		// Add analysis modes
+		if scanOptions.staticDisabled() {
+			args = append(args, "--no-static")
+		}
+		if scanOptions.DynamicTesting {
+			args = append(args, "--dynamic")
+			for _, plugin := range scanOptions.DynamicPlugins {
+				args = append(args, "--dynamic-plugin", plugin)
+			}
+		}
+	}
+
 	// Add output options
 	if mergedConfig.OutputFormat != "" {
 		args = append(args, "--format", mergedConfig.OutputFormat)
@@ -206,7 +400,7 @@ func (s *TavoScanner) ScanDirectory(targetPath string, scanOptions *ScanOptions)
 		args = append(args, "--timeout", fmt.Sprintf("%d", mergedConfig.Timeout))
 	}
 
-	return s.executeScanner(args, mergedConfig.WorkingDirectory)
+	return args
 }
 
 // ScanWithPlugins scans with specific plugins
@@ -223,88 +417,287 @@ func (s *TavoScanner) ScanWithRules(targetPath, rulesPath string) (*ScanResult,
 	return s.ScanDirectory(targetPath, options)
 }
 
-// executeScanner executes the scanner subprocess
-func (s *TavoScanner) executeScanner(args []string, workingDirectory string) (*ScanResult, error) {
-	cmd := exec.Command(s.config.ScannerPath, args...)
-	cmd.Dir = workingDirectory
+// findingsOnStdout reports whether the scanner prints its findings as JSON on
+// standard output, rather than writing them to OutputFile or in another format
+func (c *ScannerConfig) findingsOnStdout() bool {
+	if c.OutputFile != "" {
+		return false
+	}
+	switch strings.ToLower(c.OutputFormat) {
+	case "", "json", StreamFormat:
+		return true
+	}
+	return false
+}
+
+// executeScanner executes the scanner subprocess and decodes its findings when
+// they are printed as JSON
+func (s *TavoScanner) executeScanner(ctx context.Context, args []string, config *ScannerConfig) (*ScanResult, error) {
+	var stdout bytes.Buffer
+	result, err := s.runScanner(ctx, args, config, &stdout, nil)
+	if err != nil {
+		return result, err
+	}
+	if !config.findingsOnStdout() {
+		result.Output = stdout.String()
+		return result, nil
+	}
+
+	findings, results, decodeErr := decodeScanOutput(stdout.Bytes())
+	if decodeErr == nil {
+		result.Findings = findings
+		result.Results = results
+		return result, nil
+	}
+
+	result.Output = stdout.String()
+	if result.ExitCode == ExitCodeFindings {
+		// The scanner reported findings that cannot be read, so they would be lost
+		return result, result.undecodableFindings(decodeErr)
+	}
+	return result, nil
+}
+
+// undecodableFindings marks a result whose scanner exited with ExitCodeFindings
+// but whose findings could not be decoded as failed
+func (r *ScanResult) undecodableFindings(decodeErr error) error {
+	r.Status = "error"
+	r.Error = strings.TrimSpace(r.Stderr)
+	if r.Error == "" {
+		r.Error = fmt.Sprintf("decode scanner output: %v", decodeErr)
+	}
+	return fmt.Errorf("%w: exit code %d without decodable findings: %w", ErrScannerFailed, r.ExitCode, decodeErr)
+}
 
-	// Set timeout
-	done := make(chan bool)
-	var result *ScanResult
-	var execErr error
+// runScanner executes the scanner subprocess, killing its process group when
+// ctx is done or config.Timeout seconds have passed. Standard output is
+// collected in stdout and, if lines is set, also written to lines as it arrives.
+func (s *TavoScanner) runScanner(ctx context.Context, args []string, config *ScannerConfig, stdout *bytes.Buffer, lines io.Writer) (*ScanResult, error) {
+	timeout := config.Timeout
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, time.Duration(timeout)*time.Second, ErrScannerTimeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, s.config.ScannerPath, args...)
+	cmd.Dir = config.WorkingDirectory
+	cmd.SysProcAttr = processGroupAttr()
+	cmd.Cancel = func() error { return killProcessGroup(cmd) }
+	cmd.WaitDelay = scannerWaitDelay
+
+	var stderr bytes.Buffer
+	cmd.Stdout = stdout
+	if lines != nil {
+		cmd.Stdout = io.MultiWriter(stdout, lines)
+	}
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	result := &ScanResult{Stderr: stderr.String()}
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
 
-	go func() {
-		output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		// Killed on cancellation or timeout; keep whatever was written before
+		result.Output = stdout.String()
+		cause := context.Cause(ctx)
+		switch {
+		case errors.Is(cause, ErrScannerTimeout):
+			result.Status = "timeout"
+			err = fmt.Errorf("%w after %d seconds", ErrScannerTimeout, timeout)
+		case errors.Is(cause, context.DeadlineExceeded):
+			result.Status = "timeout"
+			err = fmt.Errorf("%w: %w", ErrScannerTimeout, cause)
+		default:
+			result.Status = "cancelled"
+			err = fmt.Errorf("%w: %w", ErrScannerCancelled, cause)
+		}
+		result.Error = err.Error()
+		return result, err
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		// The scanner could not be started
+		result.Status = "error"
+		result.Error = err.Error()
+		return result, err
+	}
+
+	// Exit code 1 is a successful scan that found issues, unless the findings
+	// should have been printed as JSON and nothing was. Anything else non-zero,
+	// or a scanner killed by a signal, is a crash.
+	if result.ExitCode != 0 && (result.ExitCode != ExitCodeFindings || config.findingsOnStdout() && stdout.Len() == 0) {
+		result.Status = "error"
+		result.Output = stdout.String()
+		result.Error = strings.TrimSpace(stderr.String())
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("Scanner exited with code %d", result.ExitCode)
+		}
+		return result, fmt.Errorf("%w: %w", ErrScannerFailed, err)
+	}
+
+	result.Status = "success"
+	return result, nil
+}
+
+// runFiles are the temporary files written for a single scanner run
+type runFiles struct {
+	dir              string
+	pluginConfigFile string
+	rulesFile        string
+}
+
+// writeRunFiles writes the plugin configuration and custom rules of a merged
+// configuration to a temporary directory. The plugin configuration is a single
+// JSON object keyed by plugin name; custom rules are merged with the rules
+// file, if any, so the scanner gets one rules file. Call remove once the
+// scanner has exited.
+func writeRunFiles(mergedConfig *ScannerConfig) (*runFiles, error) {
+	files := &runFiles{}
+	if len(mergedConfig.PluginConfig) == 0 && len(mergedConfig.CustomRules) == 0 {
+		return files, nil
+	}
+
+	rules := mergedConfig.CustomRules
+	if len(rules) > 0 && mergedConfig.RulesPath != "" {
+		var err error
+		rules, err = mergeRules(resolvePath(mergedConfig.WorkingDirectory, mergedConfig.RulesPath), mergedConfig.CustomRules)
 		if err != nil {
-			execErr = err
-			result = &ScanResult{
-				Status: "error",
-				Error:  string(output),
-			}
-			if result.Error == "" {
-				result.Error = fmt.Sprintf("Scanner exited with code %d", cmd.ProcessState.ExitCode())
-			}
-		} else {
-			outputStr := string(output)
-			result = &ScanResult{Status: "success"}
-
-			if outputStr == "" {
-				result.Results = []interface{}{}
-			} else {
-				// Try to parse as JSON
-				var results []interface{}
-				if err := json.Unmarshal([]byte(outputStr), &results); err == nil {
-					result.Results = results
-				} else {
-					result.Output = outputStr
-				}
-			}
+			return nil, err
 		}
-		done <- true
-	}()
-
-	// Wait for completion or timeout
-	select {
-	case <-done:
-		return result, execErr
-	case <-time.After(time.Duration(s.config.Timeout) * time.Second):
-		if cmd.Process != nil {
-			cmd.Process.Kill()
+	}
+
+	dir, err := os.MkdirTemp("", "tavo-scan-*")
+	if err != nil {
+		return nil, err
+	}
+	files.dir = dir
+
+	if len(mergedConfig.PluginConfig) > 0 {
+		path, err := writeJSONFile(dir, "plugins-*.json", mergedConfig.PluginConfig)
+		if err != nil {
+			files.remove()
+			return nil, fmt.Errorf("write plugin config: %w", err)
+		}
+		files.pluginConfigFile = path
+	}
+	if len(rules) > 0 {
+		path, err := writeJSONFile(dir, "rules-*.json", rules)
+		if err != nil {
+			files.remove()
+			return nil, fmt.Errorf("write custom rules: %w", err)
 		}
-		return nil, fmt.Errorf("scanner timed out after %d seconds", s.config.Timeout)
+		files.rulesFile = path
 	}
+	return files, nil
 }
 
-// CreatePluginConfig creates a temporary plugin configuration file
-func (s *TavoScanner) CreatePluginConfig(pluginName string, config map[string]interface{}) (string, error) {
-	tempFile, err := os.CreateTemp("", fmt.Sprintf("tavo-plugin-%s-*.json", pluginName))
+// mergeRules reads the YAML or JSON rules file at rulesPath and adds custom
+// rules to it. Lists under the same key, such as "rules", are concatenated;
+// other custom values replace those of the file. A file that is a list of
+// rules is read as the "rules" list.
+func mergeRules(rulesPath string, custom map[string]interface{}) (map[string]interface{}, error) {
+	data, err := os.ReadFile(rulesPath)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("parse rules file %s: %w", rulesPath, err)
+	}
+	var rules map[string]interface{}
+	switch document := document.(type) {
+	case nil:
+		rules = make(map[string]interface{})
+	case map[string]interface{}:
+		rules = document
+	case []interface{}:
+		rules = map[string]interface{}{"rules": document}
+	default:
+		return nil, fmt.Errorf("rules file %s is neither a mapping with string keys nor a list of rules", rulesPath)
 	}
-	defer tempFile.Close()
 
-	encoder := json.NewEncoder(tempFile)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(config); err != nil {
-		return "", err
+	for key, value := range custom {
+		existing, existingIsList := listValue(rules[key])
+		added, addedIsList := listValue(value)
+		if existingIsList && addedIsList {
+			rules[key] = append(existing, added...)
+		} else {
+			rules[key] = value
+		}
 	}
+	return rules, nil
+}
 
-	return tempFile.Name(), nil
+// listValue returns the elements of a slice of any type
+func listValue(value interface{}) ([]interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	list := make([]interface{}, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list, true
 }
 
-// CreateRulesFile creates a temporary rules file
-func (s *TavoScanner) CreateRulesFile(rules map[string]interface{}) (string, error) {
-	tempFile, err := os.CreateTemp("", "tavo-rules-*.json")
+// resolvePath resolves a relative path against the scanner's working directory
+func resolvePath(workingDirectory, name string) string {
+	if filepath.IsAbs(name) || workingDirectory == "" {
+		return name
+	}
+	return filepath.Join(workingDirectory, name)
+}
+
+// remove deletes the files written for the run
+func (f *runFiles) remove() {
+	if f.dir != "" {
+		os.RemoveAll(f.dir)
+	}
+}
+
+// writeJSONFile writes v as indented JSON to a new file in dir named after pattern
+func writeJSONFile(dir, pattern string, v interface{}) (string, error) {
+	tempFile, err := os.CreateTemp(dir, pattern)
 	if err != nil {
 		return "", err
 	}
-	defer tempFile.Close()
 
 	encoder := json.NewEncoder(tempFile)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(rules); err != nil {
+	err = encoder.Encode(v)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempFile.Name())
 		return "", err
 	}
 
 	return tempFile.Name(), nil
 }
+
+// safeFileName replaces the characters of name that are not safe in a file name
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// CreatePluginConfig creates a temporary plugin configuration file. The caller
+// removes it; scans write their own files from ScannerConfig.PluginConfig.
+func (s *TavoScanner) CreatePluginConfig(pluginName string, config map[string]interface{}) (string, error) {
+	return writeJSONFile("", fmt.Sprintf("tavo-plugin-%s-*.json", safeFileName(pluginName)), config)
+}
+
+// CreateRulesFile creates a temporary rules file. The caller removes it; scans
+// write their own file from ScannerConfig.CustomRules.
+func (s *TavoScanner) CreateRulesFile(rules map[string]interface{}) (string, error) {
+	return writeJSONFile("", "tavo-rules-*.json", rules)
+}
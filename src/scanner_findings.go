@@ -0,0 +1,229 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// severityAliases maps the level names used by other tools onto the Severity constants
+var severityAliases = map[string]Severity{
+	"error":         SeverityHigh,
+	"warning":       SeverityMedium,
+	"warn":          SeverityMedium,
+	"moderate":      SeverityMedium,
+	"note":          SeverityLow,
+	"information":   SeverityInfo,
+	"informational": SeverityInfo,
+	"none":          SeverityInfo,
+}
+
+// parseSeverity normalizes a severity reported by the scanner
+func parseSeverity(value string) Severity {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if severity, ok := severityAliases[value]; ok {
+		return severity
+	}
+	return Severity(value)
+}
+
+// Finding is a single issue reported by tavo-scanner
+type Finding struct {
+	RuleID      string                 `json:"rule_id"`
+	Severity    Severity               `json:"severity"`
+	Message     string                 `json:"message"`
+	File        string                 `json:"file"`
+	StartLine   int                    `json:"start_line,omitempty"`
+	StartColumn int                    `json:"start_column,omitempty"`
+	EndLine     int                    `json:"end_line,omitempty"`
+	EndColumn   int                    `json:"end_column,omitempty"`
+	Snippet     string                 `json:"snippet,omitempty"`
+	CWE         []string               `json:"cwe,omitempty"`
+	OWASP       []string               `json:"owasp,omitempty"`
+	Fingerprint string                 `json:"fingerprint,omitempty"`
+	Plugin      string                 `json:"plugin,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// UnmarshalJSON accepts the field names used by tavo-scanner plugins, including
+// Semgrep-style findings with nested "start", "end" and "extra" objects
+func (f *Finding) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	extra := objectField(raw, "extra")
+	metadata := objectField(raw, "metadata")
+	if metadata == nil {
+		metadata = objectField(extra, "metadata")
+	}
+	start := objectField(raw, "start")
+	end := objectField(raw, "end")
+	fields := []map[string]interface{}{raw, extra}
+
+	*f = Finding{
+		RuleID:      stringField(fields, "rule_id", "ruleId", "check_id", "rule"),
+		Severity:    parseSeverity(stringField(fields, "severity", "level")),
+		Message:     stringField(fields, "message", "msg", "title", "description"),
+		File:        stringField(fields, "file", "path", "file_path", "filename"),
+		StartLine:   intField([]map[string]interface{}{raw, start}, "start_line", "line", "line_number"),
+		StartColumn: intField([]map[string]interface{}{raw, start}, "start_column", "column", "col"),
+		EndLine:     intField([]map[string]interface{}{raw}, "end_line"),
+		EndColumn:   intField([]map[string]interface{}{raw}, "end_column"),
+		Snippet:     stringField(fields, "snippet", "code_snippet", "lines", "code"),
+		CWE:         listField(append(fields, metadata), "cwe", "cwe_id", "cwe_ids"),
+		OWASP:       listField(append(fields, metadata), "owasp", "owasp_ids"),
+		Fingerprint: stringField(fields, "fingerprint", "hash"),
+		Plugin:      stringField(fields, "plugin", "tool"),
+		Metadata:    metadata,
+	}
+	if f.EndLine == 0 {
+		f.EndLine = intField([]map[string]interface{}{end}, "line")
+	}
+	if f.EndColumn == 0 {
+		f.EndColumn = intField([]map[string]interface{}{end}, "col", "column")
+	}
+	if f.EndLine == 0 {
+		f.EndLine = f.StartLine
+	}
+	return nil
+}
+
+// objectField returns a nested object, or nil
+func objectField(raw map[string]interface{}, key string) map[string]interface{} {
+	object, _ := raw[key].(map[string]interface{})
+	return object
+}
+
+// stringField returns the first non-empty string found under any of keys
+func stringField(sources []map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		for _, source := range sources {
+			if value, ok := source[key].(string); ok && value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// intField returns the first number found under any of keys; numbers sent as strings are accepted
+func intField(sources []map[string]interface{}, keys ...string) int {
+	for _, key := range keys {
+		for _, source := range sources {
+			switch value := source[key].(type) {
+			case float64:
+				return int(value)
+			case string:
+				if n, err := strconv.Atoi(value); err == nil {
+					return n
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// listField returns the values under the first of keys that is set, accepting
+// a single string or a list
+func listField(sources []map[string]interface{}, keys ...string) []string {
+	for _, key := range keys {
+		for _, source := range sources {
+			switch value := source[key].(type) {
+			case string:
+				if value != "" {
+					return []string{value}
+				}
+			case []interface{}:
+				list := make([]string, 0, len(value))
+				for _, item := range value {
+					if s := fmt.Sprint(item); s != "" {
+						list = append(list, s)
+					}
+				}
+				return list
+			}
+		}
+	}
+	return nil
+}
+
+// findingsKeys are the envelope keys that may hold the findings of a scan
+var findingsKeys = []string{"findings", "results", "issues", "vulnerabilities"}
+
+// DecodeFindings decodes scanner output that is either a JSON array of findings
+// or an object holding them under "findings", "results", "issues" or
+// "vulnerabilities". Entries that are not objects are skipped.
+func DecodeFindings(data []byte) ([]Finding, error) {
+	findings, _, err := decodeScanOutput(data)
+	return findings, err
+}
+
+// decodeScanOutput decodes scanner output into typed findings and the raw entries
+func decodeScanOutput(data []byte) ([]Finding, []interface{}, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return []Finding{}, []interface{}{}, nil
+	}
+
+	var entries []json.RawMessage
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, nil, err
+		}
+		found := false
+		for _, key := range findingsKeys {
+			if value, ok := envelope[key]; ok && !bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+				if err := json.Unmarshal(value, &entries); err != nil {
+					return nil, nil, fmt.Errorf("decode %q: %w", key, err)
+				}
+				found = true
+				break
+			}
+		}
+		if !found && envelope["rule_id"] == nil && envelope["check_id"] == nil {
+			return nil, nil, fmt.Errorf("scanner output has no findings list")
+		}
+		if !found {
+			// A single finding
+			entries = []json.RawMessage{data}
+		}
+	}
+
+	findings := make([]Finding, 0, len(entries))
+	raw := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if trimmed := bytes.TrimSpace(entry); len(trimmed) == 0 || trimmed[0] != '{' {
+			continue
+		}
+		var finding Finding
+		var value interface{}
+		if err := json.Unmarshal(entry, &finding); err != nil {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal(entry, &value); err != nil {
+			return nil, nil, err
+		}
+		findings = append(findings, finding)
+		raw = append(raw, value)
+	}
+	return findings, raw, nil
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeFindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []Finding
+	}{
+		{
+			name:   "array",
+			output: `[{"rule_id": "r1", "severity": "Critical", "message": "m", "file": "b.py", "line": "10", "cwe_id": "CWE-79", "plugin": "p"}, "skipped"]`,
+			want: []Finding{{
+				RuleID: "r1", Severity: SeverityCritical, Message: "m", File: "b.py",
+				StartLine: 10, EndLine: 10, CWE: []string{"CWE-79"}, Plugin: "p",
+			}},
+		},
+		{
+			name:   "findings envelope",
+			output: `{"scan_id": "s1", "findings": [{"ruleId": "r2", "level": "warning", "path": "a.go", "start_line": 3, "end_line": 4, "owasp": ["A03:2021"]}]}`,
+			want: []Finding{{
+				RuleID: "r2", Severity: SeverityMedium, File: "a.go",
+				StartLine: 3, EndLine: 4, OWASP: []string{"A03:2021"},
+			}},
+		},
+		{
+			name:   "issues envelope after null findings",
+			output: `{"findings": null, "issues": [{"rule_id": "r3", "file_path": "c.js"}]}`,
+			want:   []Finding{{RuleID: "r3", File: "c.js"}},
+		},
+		{
+			name:   "single finding",
+			output: `{"check_id": "r4", "filename": "d.rb", "severity": "note"}`,
+			want:   []Finding{{RuleID: "r4", File: "d.rb", Severity: SeverityLow}},
+		},
+		{
+			name: "semgrep",
+			output: `{"results": [{
+				"check_id": "go.lang.security.sqli",
+				"path": "db.go",
+				"start": {"line": 3, "col": 2},
+				"end": {"line": 5, "col": 9},
+				"extra": {
+					"message": "SQL built from user input",
+					"severity": "ERROR",
+					"lines": "db.Query(q)",
+					"fingerprint": "abc",
+					"metadata": {"cwe": ["CWE-89: SQL Injection"], "owasp": "A03:2021"}
+				}
+			}], "errors": []}`,
+			want: []Finding{{
+				RuleID: "go.lang.security.sqli", Severity: SeverityHigh, Message: "SQL built from user input",
+				File: "db.go", StartLine: 3, StartColumn: 2, EndLine: 5, EndColumn: 9,
+				Snippet: "db.Query(q)", CWE: []string{"CWE-89: SQL Injection"}, OWASP: []string{"A03:2021"},
+				Fingerprint: "abc",
+				Metadata:    map[string]interface{}{"cwe": []interface{}{"CWE-89: SQL Injection"}, "owasp": "A03:2021"},
+			}},
+		},
+		{
+			name:   "empty output",
+			output: " \n",
+			want:   []Finding{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFindings([]byte(tt.output))
+			if err != nil {
+				t.Fatalf("DecodeFindings: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeFindings =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFindingsErrors(t *testing.T) {
+	for _, output := range []string{`{"summary": {"total": 0}}`, `{"findings": {"rule_id": "r"}}`, `not json`, `[{"rule_id": "r"}`} {
+		if findings, err := DecodeFindings([]byte(output)); err == nil {
+			t.Errorf("DecodeFindings(%s) = %+v, want an error", output, findings)
+		}
+	}
+}
+
+func TestDecodeScanOutputKeepsRawResults(t *testing.T) {
+	_, results, err := decodeScanOutput([]byte(`{"findings": [{"rule_id": "r", "custom": true}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{map[string]interface{}{"rule_id": "r", "custom": true}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+}
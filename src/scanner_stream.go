@@ -0,0 +1,347 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StreamFormat is the output format requested from tavo-scanner in streaming mode
+const StreamFormat = "ndjson"
+
+// streamBufferSize is the capacity of a ScanStream's events channel
+const streamBufferSize = 64
+
+// ScanEventType identifies the kind of a streamed scan event
+type ScanEventType string
+
+const (
+	ScanEventFinding  ScanEventType = "finding"
+	ScanEventProgress ScanEventType = "progress"
+)
+
+// ScanProgress reports how far a running scan has got
+type ScanProgress struct {
+	Phase        string  `json:"phase,omitempty"`
+	Message      string  `json:"message,omitempty"`
+	FilesScanned int     `json:"files_scanned,omitempty"`
+	FilesTotal   int     `json:"files_total,omitempty"`
+	Percent      float64 `json:"percent,omitempty"`
+
+	// Findings received so far, counted by the SDK
+	FindingsCount int `json:"findings_count"`
+}
+
+// ScanEvent is a finding or progress update read from the scanner's output
+// while it runs. Finding is set for ScanEventFinding and Progress for
+// ScanEventProgress. Other event types the scanner prints, such as a summary,
+// are delivered with the type given by the scanner and the decoded line in Raw.
+type ScanEvent struct {
+	Type     ScanEventType
+	Finding  *Finding
+	Progress *ScanProgress
+	Raw      map[string]interface{}
+}
+
+// ScanStream is a scan whose findings and progress are delivered as they are
+// printed by the scanner
+type ScanStream struct {
+	events chan ScanEvent
+	done   chan struct{}
+	result *ScanResult
+	err    error
+}
+
+// ScanDirectoryStream starts a scan that reads the scanner's output as NDJSON
+// and delivers findings and progress on Events as they appear. Receive from
+// Events until it is closed, then call Wait for the aggregate result; the
+// scanner is paused while Events is full. Lines that are not JSON are
+// delivered as progress messages, and JSON events of other types as they are.
+//
+// Findings have to arrive on stdout to be streamed, so the scanner always runs
+// with StreamFormat and without an output file: ScannerConfig.OutputFormat and
+// OutputFile are ignored, and setting ScanOptions.OutputFile fails with
+// ErrInvalidScanOptions.
+func (s *TavoScanner) ScanDirectoryStream(ctx context.Context, targetPath string, scanOptions *ScanOptions) (*ScanStream, error) {
+	if s.config.ScannerPath == "" {
+		return nil, fmt.Errorf("tavo-scanner binary not found. Please install tavo-cli or set ScannerPath")
+	}
+	if scanOptions != nil && scanOptions.OutputFile != "" {
+		return nil, fmt.Errorf("%w: OutputFile is set but streamed findings are read from stdout", ErrInvalidScanOptions)
+	}
+
+	mergedConfig, err := s.mergeConfig(scanOptions)
+	if err != nil {
+		return nil, err
+	}
+	mergedConfig.OutputFormat = StreamFormat
+	mergedConfig.OutputFile = ""
+	files, err := writeRunFiles(mergedConfig)
+	if err != nil {
+		return nil, err
+	}
+	args := scanArgs(targetPath, mergedConfig, scanOptions, files)
+
+	stream := &ScanStream{
+		events: make(chan ScanEvent, streamBufferSize),
+		done:   make(chan struct{}),
+	}
+	go stream.run(ctx, s, args, mergedConfig, files)
+	return stream, nil
+}
+
+// Events returns the channel events are delivered on. It is closed when the scanner exits.
+func (st *ScanStream) Events() <-chan ScanEvent {
+	return st.events
+}
+
+// Wait blocks until the scanner has exited and returns the aggregate result,
+// with the same error semantics as ScanDirectoryContext
+func (st *ScanStream) Wait() (*ScanResult, error) {
+	<-st.done
+	return st.result, st.err
+}
+
+// run executes the scanner and decodes its output line by line
+func (st *ScanStream) run(ctx context.Context, s *TavoScanner, args []string, config *ScannerConfig, files *runFiles) {
+	defer close(st.done)
+	defer close(st.events)
+	defer files.remove()
+
+	if config.Timeout > 0 {
+		// Also stop delivering events once the scanner has timed out
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, time.Duration(config.Timeout)*time.Second, ErrScannerTimeout)
+		defer cancel()
+	}
+	decoder := &streamDecoder{ctx: ctx, events: st.events}
+	var stdout bytes.Buffer
+	result, err := s.runScanner(ctx, args, config, &stdout, decoder)
+	decoder.flush()
+
+	if err == nil {
+		if len(decoder.findings) == 0 {
+			// The scanner printed a single JSON document instead of NDJSON
+			findings, results, decodeErr := decodeScanOutput(stdout.Bytes())
+			if decodeErr == nil {
+				for i := range findings {
+					decoder.addFinding(findings[i], results[i])
+				}
+			} else if result.ExitCode == ExitCodeFindings {
+				result.Output = stdout.String()
+				err = result.undecodableFindings(decodeErr)
+			}
+		}
+		result.Findings = decoder.findings
+		result.Results = decoder.results
+	}
+	st.result, st.err = result, err
+}
+
+// progressCount matches "12/40" in a plain-text progress line
+var progressCount = regexp.MustCompile(`(\d+)\s*/\s*(\d+)`)
+
+// progressPercent matches "45%" or "45.5%" in a plain-text progress line
+var progressPercent = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+
+// streamDecoder turns the scanner's output into events as it is written
+type streamDecoder struct {
+	ctx      context.Context
+	events   chan<- ScanEvent
+	mu       sync.Mutex
+	pending  []byte
+	document []byte
+	findings []Finding
+	results  []interface{}
+}
+
+// Write implements io.Writer, decoding every complete line
+func (d *streamDecoder) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pending = append(d.pending, p...)
+	for {
+		i := bytes.IndexByte(d.pending, '\n')
+		if i < 0 {
+			break
+		}
+		line := d.pending[:i]
+		d.pending = d.pending[i+1:]
+		d.decodeLine(line)
+	}
+	return len(p), nil
+}
+
+// flush decodes a last line that was not terminated by a newline
+func (d *streamDecoder) flush() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.pending) > 0 {
+		d.decodeLine(d.pending)
+		d.pending = nil
+	}
+	if d.document != nil {
+		d.emitDocument(d.document)
+		d.document = nil
+	}
+}
+
+// decodeLine emits the events carried by one line of output. A JSON document
+// printed over several lines is collected until a line closes it at the first
+// column, then decoded as a whole.
+func (d *streamDecoder) decodeLine(line []byte) {
+	if d.document != nil {
+		d.document = append(append(d.document, line...), '\n')
+		if len(line) > 0 && (line[0] == '}' || line[0] == ']') && json.Valid(d.document) {
+			d.emitDocument(d.document)
+			d.document = nil
+		}
+		return
+	}
+
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	if (line[0] == '{' || line[0] == '[') && !json.Valid(line) {
+		d.document = append(append([]byte{}, line...), '\n')
+		return
+	}
+	if line[0] == '[' {
+		d.emitDocument(line)
+		return
+	}
+	if line[0] != '{' {
+		// Lines of a pretty-printed JSON document are not progress messages
+		if !bytes.ContainsAny(line[:1], `[]}"`) {
+			d.emitProgress(textProgress(string(line)))
+		}
+		return
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return
+	}
+	sources := []map[string]interface{}{raw}
+	if data := objectField(raw, "data"); data != nil {
+		sources = []map[string]interface{}{data, raw}
+	}
+
+	switch eventType := stringField([]map[string]interface{}{raw}, "type", "event"); {
+	case eventType == "" && hasFindingsList(raw):
+		// A whole report on one line
+		d.emitDocument(line)
+	case eventType == "finding" || eventType == "" && stringField(sources, "rule_id", "ruleId", "check_id") != "":
+		payload := sources[0]
+		if finding := objectField(raw, "finding"); finding != nil {
+			payload = finding
+		}
+		d.emitFinding(payload)
+	case eventType == "progress" || eventType == "":
+		d.emitProgress(&ScanProgress{
+			Phase:        stringField(sources, "phase", "stage"),
+			Message:      stringField(sources, "message", "msg"),
+			FilesScanned: intField(sources, "files_scanned", "scanned", "current"),
+			FilesTotal:   intField(sources, "files_total", "total"),
+			Percent:      floatField(sources, "percent", "progress"),
+		})
+	default:
+		d.send(ScanEvent{Type: ScanEventType(eventType), Raw: raw})
+	}
+}
+
+// emitDocument delivers the findings of a complete JSON document; documents
+// without findings are ignored
+func (d *streamDecoder) emitDocument(data []byte) {
+	findings, results, err := decodeScanOutput(data)
+	if err != nil {
+		return
+	}
+	for i := range findings {
+		d.addFinding(findings[i], results[i])
+	}
+}
+
+// hasFindingsList reports whether an object holds a list of findings
+func hasFindingsList(raw map[string]interface{}) bool {
+	for _, key := range findingsKeys {
+		if _, ok := raw[key].([]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// emitFinding decodes and delivers a finding
+func (d *streamDecoder) emitFinding(payload map[string]interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	var finding Finding
+	if err := json.Unmarshal(data, &finding); err != nil {
+		return
+	}
+	d.addFinding(finding, payload)
+}
+
+// addFinding records a finding and delivers it
+func (d *streamDecoder) addFinding(finding Finding, raw interface{}) {
+	d.findings = append(d.findings, finding)
+	d.results = append(d.results, raw)
+	d.send(ScanEvent{Type: ScanEventFinding, Finding: &finding})
+}
+
+// emitProgress delivers a progress update
+func (d *streamDecoder) emitProgress(progress *ScanProgress) {
+	if progress.Percent == 0 && progress.FilesTotal > 0 {
+		progress.Percent = float64(progress.FilesScanned) * 100 / float64(progress.FilesTotal)
+	}
+	progress.FindingsCount = len(d.findings)
+	d.send(ScanEvent{Type: ScanEventProgress, Progress: progress})
+}
+
+// send delivers an event unless the scan has been cancelled
+func (d *streamDecoder) send(event ScanEvent) {
+	select {
+	case d.events <- event:
+	case <-d.ctx.Done():
+	}
+}
+
+// textProgress reads counts and percentages from a plain-text progress line
+func textProgress(line string) *ScanProgress {
+	progress := &ScanProgress{Message: line}
+	if match := progressCount.FindStringSubmatch(line); match != nil {
+		progress.FilesScanned, _ = strconv.Atoi(match[1])
+		progress.FilesTotal, _ = strconv.Atoi(match[2])
+	}
+	if match := progressPercent.FindStringSubmatch(line); match != nil {
+		progress.Percent, _ = strconv.ParseFloat(match[1], 64)
+	}
+	return progress
+}
+
+// floatField returns the first number found under any of keys
+func floatField(sources []map[string]interface{}, keys ...string) float64 {
+	for _, key := range keys {
+		for _, source := range sources {
+			switch value := source[key].(type) {
+			case float64:
+				return value
+			case string:
+				if n, err := strconv.ParseFloat(value, 64); err == nil {
+					return n
+				}
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,234 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// decodeStream feeds output to a stream decoder in small chunks and returns the events
+func decodeStream(t *testing.T, output string) []ScanEvent {
+	t.Helper()
+	events := make(chan ScanEvent, 100)
+	decoder := &streamDecoder{ctx: context.Background(), events: events}
+	for i := 0; i < len(output); i += 7 {
+		decoder.Write([]byte(output[i:min(i+7, len(output))]))
+	}
+	decoder.flush()
+	close(events)
+
+	var got []ScanEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	return got
+}
+
+func TestStreamDecoder(t *testing.T) {
+	output := `{"type": "progress", "phase": "parse", "files_scanned": 1, "files_total": 4}
+{"rule_id": "r1", "file": "a.go", "line": 3}
+{"type": "finding", "data": {"rule_id": "r2", "file": "b.go"}}
+{"event": "finding", "finding": {"check_id": "r3", "path": "c.go"}}
+{"type": "progress", "data": {"message": "halfway", "percent": 50}}
+{"type": "summary", "total": 3}
+Scanning 12/40 files
+45% done
+
+{"files_scanned": 40, "files_total": 40}
+{"type": "finding", "data": {"rule_id": "r4"`
+
+	tests := []struct {
+		eventType ScanEventType
+		finding   *Finding
+		progress  *ScanProgress
+		raw       map[string]interface{}
+	}{
+		{eventType: ScanEventProgress, progress: &ScanProgress{Phase: "parse", FilesScanned: 1, FilesTotal: 4, Percent: 25}},
+		{eventType: ScanEventFinding, finding: &Finding{RuleID: "r1", File: "a.go", StartLine: 3, EndLine: 3}},
+		{eventType: ScanEventFinding, finding: &Finding{RuleID: "r2", File: "b.go"}},
+		{eventType: ScanEventFinding, finding: &Finding{RuleID: "r3", File: "c.go"}},
+		{eventType: ScanEventProgress, progress: &ScanProgress{Message: "halfway", Percent: 50, FindingsCount: 3}},
+		{eventType: "summary", raw: map[string]interface{}{"type": "summary", "total": float64(3)}},
+		{eventType: ScanEventProgress, progress: &ScanProgress{Message: "Scanning 12/40 files", FilesScanned: 12, FilesTotal: 40, Percent: 30, FindingsCount: 3}},
+		{eventType: ScanEventProgress, progress: &ScanProgress{Message: "45% done", Percent: 45, FindingsCount: 3}},
+		{eventType: ScanEventProgress, progress: &ScanProgress{FilesScanned: 40, FilesTotal: 40, Percent: 100, FindingsCount: 3}},
+	}
+
+	got := decodeStream(t, output)
+	if len(got) != len(tests) {
+		t.Fatalf("got %d events, want %d: %+v", len(got), len(tests), got)
+	}
+	for i, tt := range tests {
+		event := got[i]
+		if event.Type != tt.eventType {
+			t.Errorf("event %d: Type = %s, want %s", i, event.Type, tt.eventType)
+			continue
+		}
+		if !reflect.DeepEqual(event.Finding, tt.finding) {
+			t.Errorf("event %d: Finding = %+v, want %+v", i, event.Finding, tt.finding)
+		}
+		if !reflect.DeepEqual(event.Progress, tt.progress) {
+			t.Errorf("event %d: Progress = %+v, want %+v", i, event.Progress, tt.progress)
+		}
+		if !reflect.DeepEqual(event.Raw, tt.raw) {
+			t.Errorf("event %d: Raw = %v, want %v", i, event.Raw, tt.raw)
+		}
+	}
+}
+
+func TestStreamDecoderPrettyPrintedDocument(t *testing.T) {
+	output := `Scanning 2 files
+{
+  "findings": [
+    {"rule_id": "r1"},
+    {
+      "rule_id": "r2"
+    }
+  ]
+}
+[{"rule_id": "r3"}]
+{"type": "progress", "percent": 100}
+  [
+    {"rule_id": "r4"}
+  ]
+`
+	var got []string
+	for _, event := range decodeStream(t, output) {
+		switch event.Type {
+		case ScanEventFinding:
+			got = append(got, event.Finding.RuleID)
+		case ScanEventProgress:
+			got = append(got, event.Progress.Message)
+		}
+	}
+	// The indented document is decoded when the output ends
+	want := []string{"Scanning 2 files", "r1", "r2", "r3", "", "r4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestScanDirectoryStream(t *testing.T) {
+	script := `echo "$@" >&2
+echo 'Scanning 1/4 files (25%)'
+echo '{"type": "finding", "data": {"rule_id": "r1", "file": "a.go", "line": 3}}'
+echo '{"type": "progress", "files_scanned": 2, "files_total": 4}'
+echo '{"rule_id": "r2", "file": "b.go"}'
+printf '{"type": "summary", "total": 2}'
+exit 1
+`
+	config := &ScannerConfig{ScannerPath: fakeScanner(t, script), WorkingDirectory: ".", OutputFile: "out.json"}
+	stream, err := NewTavoScanner(config).ScanDirectoryStream(context.Background(), ".", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []ScanEventType
+	for event := range stream.Events() {
+		types = append(types, event.Type)
+	}
+	result, err := stream.Wait()
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	want := []ScanEventType{ScanEventProgress, ScanEventFinding, ScanEventProgress, ScanEventFinding, "summary"}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("event types = %v, want %v", types, want)
+	}
+	if len(result.Findings) != 2 || len(result.Results) != 2 || result.Findings[0].StartLine != 3 || !result.HasFindings() {
+		t.Errorf("result = %+v", result)
+	}
+	if result.Stderr != ". --format ndjson\n" {
+		t.Errorf("args = %q, want the stream format and no output file", result.Stderr)
+	}
+}
+
+func TestScanDirectoryStreamRejectsOutputFile(t *testing.T) {
+	scanner := NewTavoScanner(&ScannerConfig{ScannerPath: fakeScanner(t, "exit 0\n"), WorkingDirectory: "."})
+	if _, err := scanner.ScanDirectoryStream(context.Background(), ".", &ScanOptions{StaticAnalysis: true, OutputFile: "out.json"}); !errors.Is(err, ErrInvalidScanOptions) {
+		t.Errorf("err = %v, want ErrInvalidScanOptions", err)
+	}
+}
+
+func TestScanDirectoryStreamSingleDocument(t *testing.T) {
+	script := `cat <<'EOF'
+{
+  "findings": [
+    {"rule_id": "r1", "file": "a.go"},
+    {"rule_id": "r2", "file": "b.go"}
+  ]
+}
+EOF
+exit 1
+`
+	stream, err := NewTavoScanner(&ScannerConfig{ScannerPath: fakeScanner(t, script), WorkingDirectory: "."}).ScanDirectoryStream(context.Background(), ".", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var findings []string
+	for event := range stream.Events() {
+		if event.Type != ScanEventFinding {
+			t.Errorf("unexpected %s event: %+v", event.Type, event)
+			continue
+		}
+		findings = append(findings, event.Finding.RuleID)
+	}
+	result, err := stream.Wait()
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if !reflect.DeepEqual(findings, []string{"r1", "r2"}) || len(result.Findings) != 2 {
+		t.Errorf("findings = %q, result = %+v", findings, result)
+	}
+}
+
+func TestScanDirectoryStreamUndecodableFindings(t *testing.T) {
+	stream, err := NewTavoScanner(&ScannerConfig{ScannerPath: fakeScanner(t, "echo 'Found 2 issues'\nexit 1\n"), WorkingDirectory: "."}).ScanDirectoryStream(context.Background(), ".", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range stream.Events() {
+	}
+	if result, err := stream.Wait(); !errors.Is(err, ErrScannerFailed) || result.Status != "error" {
+		t.Errorf("Wait = %+v, %v; want ErrScannerFailed", result, err)
+	}
+}
+
+func TestScanDirectoryStreamCancelledWhileFull(t *testing.T) {
+	script := "while true; do echo '{\"rule_id\": \"r\"}'; done\n"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewTavoScanner(&ScannerConfig{ScannerPath: fakeScanner(t, script), WorkingDirectory: "."}).ScanDirectoryStream(ctx, ".", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the channel to fill up without receiving from it
+	deadline := time.Now().Add(5 * time.Second)
+	for len(stream.Events()) < cap(stream.Events()) {
+		if time.Now().After(deadline) {
+			t.Fatal("events channel never filled up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	var result *ScanResult
+	go func() {
+		result, err = stream.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Wait did not return after cancellation")
+	}
+	if !errors.Is(err, ErrScannerCancelled) || result.Status != "cancelled" {
+		t.Errorf("Wait = %+v, %v; want ErrScannerCancelled", result, err)
+	}
+	for range stream.Events() {
+	}
+}
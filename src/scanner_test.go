@@ -0,0 +1,372 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeScanner writes a shell script that stands in for tavo-scanner
+func fakeScanner(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake scanner is a shell script")
+	}
+	path := filepath.Join(t.TempDir(), "tavo-scanner")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScanDirectoryExitCodes(t *testing.T) {
+	tests := []struct {
+		name         string
+		script       string
+		wantErr      error
+		wantStatus   string
+		wantFindings int
+		wantError    string
+	}{
+		{"no findings", "echo '[]'\n", nil, "success", 0, ""},
+		{"findings", "echo log >&2\necho '{\"findings\": [{\"rule_id\": \"r\"}]}'\nexit 1\n", nil, "success", 1, ""},
+		{"plain output", "echo done\n", nil, "success", 0, ""},
+		{"undecodable findings", "echo 'panic: bad plugin' >&2\necho '{\"findings\": [{\"rule_id\"'\nexit 1\n", ErrScannerFailed, "error", 0, "panic: bad plugin"},
+		{"undecodable findings without stderr", "echo 'Found 3 issues'\nexit 1\n", ErrScannerFailed, "error", 0, ""},
+		{"exit 1 without output", "exit 1\n", ErrScannerFailed, "error", 0, "Scanner exited with code 1"},
+		{"crash", "echo boom >&2\nexit 2\n", ErrScannerFailed, "error", 0, "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := NewTavoScanner(&ScannerConfig{ScannerPath: fakeScanner(t, tt.script), WorkingDirectory: "."})
+			result, err := scanner.ScanDirectory(".", nil)
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("ScanDirectory error = %v, want %v", err, tt.wantErr)
+			}
+			if result.Status != tt.wantStatus || len(result.Findings) != tt.wantFindings {
+				t.Errorf("result = %+v, want status %s with %d findings", result, tt.wantStatus, tt.wantFindings)
+			}
+			if tt.wantError != "" && result.Error != tt.wantError {
+				t.Errorf("Error = %q, want %q", result.Error, tt.wantError)
+			}
+			if tt.wantStatus == "error" && result.Error == "" {
+				t.Error("Error is empty")
+			}
+		})
+	}
+}
+
+func TestScanDirectoryExitCodesWithoutJSONOnStdout(t *testing.T) {
+	sarif := `{"version": "2.1.0", "runs": [{"results": [{"ruleId": "r"}]}]}`
+	tests := []struct {
+		name       string
+		config     ScannerConfig
+		script     string
+		wantErr    error
+		wantOutput string
+	}{
+		{"output file", ScannerConfig{OutputFile: "out.json"}, "echo 'Found 3 issues'\nexit 1\n", nil, "Found 3 issues\n"},
+		{"output file without output", ScannerConfig{OutputFile: "out.json"}, "exit 1\n", nil, ""},
+		{"sarif", ScannerConfig{OutputFormat: "sarif"}, "echo '" + sarif + "'\nexit 1\n", nil, sarif + "\n"},
+		{"sarif crash", ScannerConfig{OutputFormat: "sarif"}, "echo boom >&2\nexit 2\n", ErrScannerFailed, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.ScannerPath = fakeScanner(t, tt.script)
+			config.WorkingDirectory = t.TempDir()
+			result, err := NewTavoScanner(&config).ScanDirectory(".", nil)
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("ScanDirectory error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			// The findings are not on stdout, so exit code 1 alone reports them
+			if result.Status != "success" || !result.HasFindings() || len(result.Findings) != 0 || result.Output != tt.wantOutput {
+				t.Errorf("result = %+v, want a successful scan with findings and output %q", result, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestScanArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ScannerConfig
+		options *ScanOptions
+		files   runFiles
+		want    string
+	}{
+		{
+			name:   "configuration only",
+			config: ScannerConfig{Plugins: []string{"secrets"}, RulesPath: "rules.yaml", OutputFormat: "json", Timeout: 300},
+			want:   "src --plugin secrets --rules rules.yaml --format json --timeout 300",
+		},
+		{
+			name:    "default options keep the configuration",
+			config:  ScannerConfig{OutputFormat: "sarif", OutputFile: "out.sarif", Timeout: 60},
+			options: NewScanOptions(),
+			want:    "src --format sarif --output out.sarif --timeout 60",
+		},
+		{
+			name:    "options override output and timeout",
+			config:  ScannerConfig{OutputFormat: "json", Timeout: 300},
+			options: &ScanOptions{StaticAnalysis: true, OutputFormat: "sarif", OutputFile: "out.sarif", Timeout: 30},
+			want:    "src --format sarif --output out.sarif --timeout 30",
+		},
+		{
+			name:    "negative timeout",
+			config:  ScannerConfig{Timeout: 300},
+			options: &ScanOptions{Timeout: -1},
+			want:    "src",
+		},
+		{
+			name:    "plugins",
+			config:  ScannerConfig{Plugins: []string{"secrets"}, PluginDir: "/cache/installed"},
+			options: &ScanOptions{StaticPlugins: []string{"sqli", "secrets"}},
+			want:    "src --plugin-dir /cache/installed --plugin secrets --plugin sqli",
+		},
+		{
+			name:    "replaced plugins and rules",
+			config:  ScannerConfig{Plugins: []string{"secrets"}, RulesPath: "rules.yaml"},
+			options: &ScanOptions{StaticPlugins: []string{"sqli"}, ReplacePlugins: true, ReplaceRules: true},
+			want:    "src --plugin sqli",
+		},
+		{
+			name:    "static rules",
+			config:  ScannerConfig{RulesPath: "rules.yaml"},
+			options: &ScanOptions{StaticRules: "other.yaml"},
+			want:    "src --rules other.yaml",
+		},
+		{
+			name:    "file patterns",
+			options: &ScanOptions{IncludePatterns: []string{"src/**", "*.go"}, ExcludePatterns: []string{"vendor/*"}},
+			want:    "src --include src/** --include *.go --exclude vendor/*",
+		},
+		{
+			name:    "dynamic only",
+			options: &ScanOptions{DynamicTesting: true, DynamicPlugins: []string{"fuzz", "xss"}},
+			want:    "src --no-static --dynamic --dynamic-plugin fuzz --dynamic-plugin xss",
+		},
+		{
+			name:    "static and dynamic",
+			options: &ScanOptions{StaticAnalysis: true, DynamicTesting: true},
+			want:    "src --dynamic",
+		},
+		{
+			name:    "static analysis off without dynamic testing",
+			options: &ScanOptions{},
+			want:    "src",
+		},
+		{
+			name:   "run files",
+			config: ScannerConfig{RulesPath: "rules.yaml"},
+			files:  runFiles{pluginConfigFile: "/tmp/plugins.json", rulesFile: "/tmp/rules.json"},
+			want:   "src --plugin-config /tmp/plugins.json --rules /tmp/rules.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := NewTavoScanner(&tt.config).mergeConfig(tt.options)
+			if err != nil {
+				t.Fatalf("mergeConfig: %v", err)
+			}
+			if got := strings.Join(scanArgs("src", merged, tt.options, &tt.files), " "); got != tt.want {
+				t.Errorf("scanArgs =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanWithPluginsKeepsConfiguration(t *testing.T) {
+	config := &ScannerConfig{ScannerPath: fakeScanner(t, `echo "$@" >&2`+"\n"), WorkingDirectory: ".", OutputFormat: "sarif", Timeout: 60}
+	result, err := NewTavoScanner(config).ScanWithPlugins("src", []string{"sqli"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"src", "--plugin", "sqli", "--format", "sarif", "--timeout", "60"}
+	if got := strings.Fields(result.Stderr); !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestWriteRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	rules := "rules:\n  - id: from-file\n    pattern: eval(...)\nversion: 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := &ScannerConfig{
+		WorkingDirectory: dir,
+		RulesPath:        "rules.yaml",
+		CustomRules: map[string]interface{}{
+			"rules":   []map[string]interface{}{{"id": "custom"}},
+			"version": 2,
+		},
+		PluginConfig: map[string]interface{}{
+			"sqli":    map[string]interface{}{"strict": true},
+			"secrets": map[string]interface{}{"entropy": 4.5},
+		},
+	}
+
+	files, err := writeRunFiles(config)
+	if err != nil {
+		t.Fatalf("writeRunFiles: %v", err)
+	}
+	defer files.remove()
+
+	args := strings.Join(scanArgs("src", config, nil, files), " ")
+	if strings.Count(args, "--rules") != 1 || strings.Count(args, "--plugin-config") != 1 {
+		t.Errorf("args = %q, want one --rules and one --plugin-config", args)
+	}
+
+	readJSON := func(path string) interface{} {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	wantRules := map[string]interface{}{
+		"rules":   []interface{}{map[string]interface{}{"id": "from-file", "pattern": "eval(...)"}, map[string]interface{}{"id": "custom"}},
+		"version": float64(2),
+	}
+	if got := readJSON(files.rulesFile); !reflect.DeepEqual(got, wantRules) {
+		t.Errorf("rules file = %v, want %v", got, wantRules)
+	}
+	wantPlugins := map[string]interface{}{
+		"sqli":    map[string]interface{}{"strict": true},
+		"secrets": map[string]interface{}{"entropy": 4.5},
+	}
+	if got := readJSON(files.pluginConfigFile); !reflect.DeepEqual(got, wantPlugins) {
+		t.Errorf("plugin config file = %v, want %v", got, wantPlugins)
+	}
+
+	config.RulesPath = "missing.yaml"
+	if _, err := writeRunFiles(config); err == nil {
+		t.Error("writeRunFiles accepted a missing rules file")
+	}
+}
+
+func TestMergeRules(t *testing.T) {
+	custom := map[string]interface{}{"rules": []interface{}{map[string]interface{}{"id": "custom"}}}
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "yaml list",
+			file:    "rules.yaml",
+			content: "- id: from-file\n",
+			want:    map[string]interface{}{"rules": []interface{}{map[string]interface{}{"id": "from-file"}, map[string]interface{}{"id": "custom"}}},
+		},
+		{
+			name:    "json list",
+			file:    "rules.json",
+			content: `[{"id": "from-file"}]`,
+			want:    map[string]interface{}{"rules": []interface{}{map[string]interface{}{"id": "from-file"}, map[string]interface{}{"id": "custom"}}},
+		},
+		{
+			name:    "empty",
+			file:    "rules.yaml",
+			content: "",
+			want:    custom,
+		},
+		{
+			name:    "scalar",
+			file:    "rules.yaml",
+			content: "eval(...)\n",
+			wantErr: "rules.yaml is neither a mapping",
+		},
+		{
+			name:    "non-string keys",
+			file:    "rules.yaml",
+			content: "1: a\n",
+			wantErr: "rules.yaml is neither a mapping",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := mergeRules(path, custom)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeRules: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFilesRemoved(t *testing.T) {
+	script := `for arg in "$@"; do [ -f "$arg" ] && echo "$arg" >&2; done
+case "$1" in
+success) echo '[]' ;;
+*) sleep 30 ;;
+esac
+`
+	tests := []struct {
+		target  string
+		timeout int
+		cancel  bool
+		wantErr error
+	}{
+		{"success", 0, false, nil},
+		{"timeout", 1, false, ErrScannerTimeout},
+		{"cancel", 0, true, ErrScannerCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			config := &ScannerConfig{
+				ScannerPath:      fakeScanner(t, script),
+				WorkingDirectory: ".",
+				PluginConfig:     map[string]interface{}{"sqli": map[string]interface{}{"strict": true}},
+				CustomRules:      map[string]interface{}{"rules": []interface{}{}},
+			}
+			tmp := t.TempDir()
+			t.Setenv("TMPDIR", tmp)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				time.AfterFunc(300*time.Millisecond, cancel)
+			}
+			result, err := NewTavoScanner(config).ScanDirectoryContext(ctx, tt.target, &ScanOptions{Timeout: tt.timeout})
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("ScanDirectoryContext error = %v, want %v", err, tt.wantErr)
+			}
+			if passed := strings.Fields(result.Stderr); len(passed) != 2 {
+				t.Errorf("the scanner saw files %q, want the plugin config and rules", passed)
+			}
+			if left, _ := filepath.Glob(filepath.Join(tmp, "tavo-scan-*")); len(left) != 0 {
+				t.Errorf("run files were not removed: %q", left)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package scanner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// processGroupAttr starts the scanner in its own process group so that it can
+// be killed together with the processes it spawns
+func processGroupAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the scanner's process group
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+		return cmd.Process.Kill()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package scanner
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// processGroupAttr starts the scanner in its own process group so that it can
+// be killed together with the processes it spawns
+func processGroupAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup kills the scanner and its child processes
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run(); err != nil {
+		return cmd.Process.Kill()
+	}
+	return nil
+}
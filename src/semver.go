@@ -0,0 +1,430 @@
+package tavo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as used by plugins and rule bundles
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+// ParseVersion parses a semantic version. A leading "v" is accepted and
+// missing minor or patch numbers are taken as zero, so "v1.2" is 1.2.0.
+func ParseVersion(value string) (Version, error) {
+	v, parts, err := parsePartialVersion(value)
+	if err != nil {
+		return Version{}, err
+	}
+	if parts == 0 {
+		return Version{}, fmt.Errorf("tavo: invalid version %q", value)
+	}
+	return v, nil
+}
+
+// String formats the version
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsPrerelease reports whether the version has a prerelease suffix such as "-beta.1"
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0 or 1 depending on whether v orders before, with or
+// after other. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// sameRelease reports whether both versions share major, minor and patch
+func (v Version) sameRelease(other Version) bool {
+	return v.Major == other.Major && v.Minor == other.Minor && v.Patch == other.Patch
+}
+
+// comparePrerelease orders prerelease suffixes; a release orders after all of its prereleases
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		ln, lerr := strconv.Atoi(left[i])
+		rn, rerr := strconv.Atoi(right[i])
+		switch {
+		case lerr == nil && rerr == nil:
+			if ln != rn {
+				return sign(ln - rn)
+			}
+		case lerr == nil:
+			// Numeric identifiers order before alphanumeric ones
+			return -1
+		case rerr == nil:
+			return 1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(left) - len(right))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// parsePartialVersion parses a version that may leave out trailing numbers or
+// use "x" or "*" wildcards, returning how many numbers were given
+func parsePartialVersion(value string) (Version, int, error) {
+	invalid := fmt.Errorf("tavo: invalid version %q", value)
+	s := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(value), "="), "v")
+	s = strings.TrimPrefix(s, "V")
+
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build, s = s[i+1:], s[:i]
+		if !validIdentifiers(v.Build) {
+			return Version{}, 0, invalid
+		}
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease, s = s[i+1:], s[:i]
+		if !validIdentifiers(v.Prerelease) {
+			return Version{}, 0, invalid
+		}
+	}
+	if s == "" {
+		return Version{}, 0, invalid
+	}
+
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return Version{}, 0, invalid
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	parts := 0
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			break
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 || (len(field) > 1 && field[0] == '0') {
+			return Version{}, 0, invalid
+		}
+		*numbers[i] = n
+		parts++
+	}
+	if parts < 3 && (v.Prerelease != "" || parts < len(fields) && !allWildcards(fields[parts:])) {
+		return Version{}, 0, invalid
+	}
+	return v, parts, nil
+}
+
+// allWildcards reports whether every field is a wildcard
+func allWildcards(fields []string) bool {
+	for _, field := range fields {
+		if field != "x" && field != "X" && field != "*" {
+			return false
+		}
+	}
+	return true
+}
+
+// validIdentifiers checks a dot-separated prerelease or build suffix
+func validIdentifiers(s string) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Constraint is a version range such as "^1.2", "~2.0.3", ">=1.0 <2.0",
+// "1.x || 2.x" or "latest"
+type Constraint struct {
+	raw  string
+	sets [][]comparator
+}
+
+// comparator is a single bound of a range
+type comparator struct {
+	op      string
+	version Version
+}
+
+// matches reports whether v satisfies the bound
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+// ParseConstraint parses a version constraint. Comparators separated by spaces
+// or commas must all hold, and "||" separates alternatives. Supported forms:
+//
+//	latest, *, ""     any release
+//	1.2.3, =1.2.3     exactly 1.2.3
+//	1.2, 1.2.x        >=1.2.0 <1.3.0
+//	^1.2.3            >=1.2.3 <2.0.0 (^0.2.3 is >=0.2.3 <0.3.0)
+//	~1.2.3            >=1.2.3 <1.3.0
+//	>, >=, <, <=      bounds, e.g. ">=1.0 <2.0"
+//	1.0 - 2.0         >=1.0.0 <2.1.0
+//
+// Prerelease versions only match when a comparator in the same alternative
+// names a prerelease of the same major, minor and patch, as in "^2.0.0-beta".
+func ParseConstraint(value string) (*Constraint, error) {
+	constraint := &Constraint{raw: strings.TrimSpace(value)}
+	for _, alternative := range strings.Split(constraint.raw, "||") {
+		set, err := parseComparatorSet(alternative)
+		if err != nil {
+			return nil, fmt.Errorf("tavo: invalid version constraint %q: %w", value, err)
+		}
+		constraint.sets = append(constraint.sets, set)
+	}
+	return constraint, nil
+}
+
+// String returns the constraint as it was written
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether v satisfies the constraint
+func (c *Constraint) Check(v Version) bool {
+	return c.check(v, false)
+}
+
+// check reports whether v satisfies the constraint, optionally letting
+// prereleases match any range
+func (c *Constraint) check(v Version, includePrerelease bool) bool {
+	for _, set := range c.sets {
+		if setMatches(set, v, includePrerelease) {
+			return true
+		}
+	}
+	return false
+}
+
+// Latest returns the highest of versions that satisfies the constraint.
+// Entries that are not valid versions are ignored. With includePrerelease,
+// prereleases may satisfy any range.
+func (c *Constraint) Latest(versions []string, includePrerelease bool) (string, bool) {
+	best, found := "", false
+	var bestVersion Version
+	for _, candidate := range versions {
+		v, err := ParseVersion(candidate)
+		if err != nil || !c.check(v, includePrerelease) {
+			continue
+		}
+		if !found || v.Compare(bestVersion) > 0 {
+			best, bestVersion, found = candidate, v, true
+		}
+	}
+	return best, found
+}
+
+// exact returns the version when the constraint pins exactly one version
+func (c *Constraint) exact() (Version, bool) {
+	if len(c.sets) != 1 || len(c.sets[0]) != 1 || c.sets[0][0].op != "=" {
+		return Version{}, false
+	}
+	return c.sets[0][0].version, true
+}
+
+// setMatches reports whether v satisfies every comparator of a set
+func setMatches(set []comparator, v Version, includePrerelease bool) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() || includePrerelease {
+		return true
+	}
+	for _, c := range set {
+		if c.version.IsPrerelease() && c.version.sameRelease(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseComparatorSet parses comparators that must all hold
+func parseComparatorSet(value string) ([]comparator, error) {
+	tokens := strings.Fields(strings.ReplaceAll(value, ",", " "))
+	if len(tokens) == 3 && tokens[1] == "-" {
+		return parseHyphenRange(tokens[0], tokens[2])
+	}
+
+	set := []comparator{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		// Allow a space between an operator and its version, as in ">= 1.0"
+		if strings.Trim(token, "<>=^~") == "" && i+1 < len(tokens) {
+			i++
+			token += tokens[i]
+		}
+		comparators, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+// parseComparator expands one operator and partial version into bounds
+func parseComparator(token string) ([]comparator, error) {
+	if strings.EqualFold(token, "latest") || token == "*" || strings.EqualFold(token, "x") {
+		return nil, nil
+	}
+	op := ""
+	for _, prefix := range []string{">=", "<=", "~>", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(token, prefix) {
+			op, token = prefix, token[len(prefix):]
+			break
+		}
+	}
+	v, parts, err := parsePartialVersion(token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "^":
+		switch {
+		case parts == 0:
+			return nil, nil
+		case v.Major > 0 || parts == 1:
+			return between(v, Version{Major: v.Major + 1}), nil
+		case v.Minor > 0 || parts == 2:
+			return between(v, Version{Minor: v.Minor + 1}), nil
+		}
+		return between(v, Version{Minor: v.Minor, Patch: v.Patch + 1}), nil
+	case "~", "~>":
+		if parts == 0 {
+			return nil, nil
+		}
+		if parts == 1 {
+			return between(v, Version{Major: v.Major + 1}), nil
+		}
+		return between(v, Version{Major: v.Major, Minor: v.Minor + 1}), nil
+	case ">":
+		if parts == 0 {
+			return []comparator{{op: "<", version: Version{Prerelease: "0"}}}, nil
+		}
+		if parts < 3 {
+			return []comparator{{op: ">=", version: nextRelease(v, parts)}}, nil
+		}
+		return []comparator{{op: ">", version: v}}, nil
+	case ">=":
+		return []comparator{{op: ">=", version: v}}, nil
+	case "<":
+		if parts < 3 {
+			return []comparator{{op: "<", version: withPrereleaseFloor(v)}}, nil
+		}
+		return []comparator{{op: "<", version: v}}, nil
+	case "<=":
+		if parts < 3 {
+			if parts == 0 {
+				return nil, nil
+			}
+			return []comparator{{op: "<", version: withPrereleaseFloor(nextRelease(v, parts))}}, nil
+		}
+		return []comparator{{op: "<=", version: v}}, nil
+	}
+	if parts < 3 {
+		if parts == 0 {
+			return nil, nil
+		}
+		return between(v, nextRelease(v, parts)), nil
+	}
+	return []comparator{{op: "=", version: v}}, nil
+}
+
+// parseHyphenRange parses an inclusive range such as "1.0 - 2.0"
+func parseHyphenRange(from, to string) ([]comparator, error) {
+	low, _, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, err
+	}
+	high, parts, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, err
+	}
+	set := []comparator{{op: ">=", version: low}}
+	switch {
+	case parts == 3:
+		set = append(set, comparator{op: "<=", version: high})
+	case parts > 0:
+		set = append(set, comparator{op: "<", version: withPrereleaseFloor(nextRelease(high, parts))})
+	}
+	return set, nil
+}
+
+// between returns the bounds low <= v < high, where high excludes its own prereleases
+func between(low, high Version) []comparator {
+	return []comparator{{op: ">=", version: low}, {op: "<", version: withPrereleaseFloor(high)}}
+}
+
+// nextRelease returns the first release after every version matching a
+// partial version with the given number of parts
+func nextRelease(v Version, parts int) Version {
+	if parts == 1 {
+		return Version{Major: v.Major + 1}
+	}
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// withPrereleaseFloor returns the lowest prerelease of v, so an upper bound
+// of 2.0.0 also excludes 2.0.0-beta
+func withPrereleaseFloor(v Version) Version {
+	v.Prerelease = "0"
+	return v
+}
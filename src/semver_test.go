@@ -0,0 +1,259 @@
+package tavo
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.2", Version{Major: 1, Minor: 2}},
+		{"2", Version{Major: 2}},
+		{"1.0.0-beta.2", Version{Major: 1, Prerelease: "beta.2"}},
+		{"1.0.0-rc.1+build.5", Version{Major: 1, Prerelease: "rc.1", Build: "build.5"}},
+		{"1.0.0+20240501", Version{Major: 1, Build: "20240501"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3.4", "01.2", "1.2-beta", "1.0.0-", "1.0.0-beta..1"} {
+		if v, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) = %v, want an error", in, v)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// Ascending, following the precedence rules of semver 2.0
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, _ := ParseVersion(ordered[i])
+		b, _ := ParseVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("%s should order before %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	a, _ := ParseVersion("1.0.0+build.1")
+	b, _ := ParseVersion("v1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Errorf("build metadata should not affect ordering")
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// latest, *, ""
+		{"latest", "0.0.1", true},
+		{"latest", "9.9.9", true},
+		{"*", "1.0.0", true},
+		{"", "1.0.0", true},
+		{"latest", "2.0.0-beta", false},
+
+		// 1.2.3, =1.2.3
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+		{"=1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.2", false},
+		{"v1.2.3", "1.2.3+build", true},
+
+		// 1.2, 1.2.x
+		{"1.2", "1.2.0", true},
+		{"1.2", "1.2.9", true},
+		{"1.2", "1.3.0", false},
+		{"1.2.x", "1.2.5", true},
+		{"1.2.x", "1.1.9", false},
+		{"1.x", "1.9.0", true},
+		{"1.x", "2.0.0", false},
+		{"1.2.*", "1.2.1", true},
+
+		// ^1.2.3
+		{"^1.2.3", "1.2.3", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.2", "1.2.0", true},
+		{"^1", "1.9.9", true},
+
+		// ~1.2.3
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2.3", "1.2.2", false},
+		{"~1", "1.9.0", true},
+		{"~>1.2", "1.2.7", true},
+		{"~>1.2", "1.3.0", false},
+
+		// >, >=, <, <=
+		{">1.2.3", "1.2.4", true},
+		{">1.2.3", "1.2.3", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{">=1.2.3", "1.2.3", true},
+		{"<1.2.3", "1.2.2", true},
+		{"<1.2.3", "1.2.3", false},
+		{"<=1.2.3", "1.2.3", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{">=1.0 <2.0", "1.9.9", true},
+		{">=1.0 <2.0", "2.0.0", false},
+		{">= 1.0, < 2", "1.5.0", true},
+		{">=1.0,<2.0", "0.9.0", false},
+
+		// 1.0 - 2.0
+		{"1.0 - 2.0", "1.0.0", true},
+		{"1.0 - 2.0", "2.0.9", true},
+		{"1.0 - 2.0", "2.1.0", false},
+		{"1.0.0 - 2.0.0", "2.0.0", true},
+		{"1.0.0 - 2.0.0", "2.0.1", false},
+
+		// Alternatives
+		{"1.x || 3.x", "3.1.0", true},
+		{"1.x || 3.x", "2.0.0", false},
+		{"<1.0 || >=2.0", "0.5.0", true},
+	}
+	for _, tt := range tests {
+		constraint, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		version, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.version, err)
+		}
+		if got := constraint.Check(version); got != tt.want {
+			t.Errorf("%q.Check(%s) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintPrereleases(t *testing.T) {
+	tests := []struct {
+		constraint        string
+		version           string
+		includePrerelease bool
+		want              bool
+	}{
+		// Excluded unless the range names a prerelease of the same release
+		{"^1.2", "2.0.0-beta", false, false},
+		{"^1.2", "1.5.0-beta", false, false},
+		{"^1.2", "1.5.0-beta", true, true},
+		{"<2.0.0", "2.0.0-beta", false, false},
+		{"<2", "2.0.0-beta", true, false},
+		{">=1.0", "1.5.0-rc.1", false, false},
+
+		// Included when the range names one
+		{"^2.0.0-beta", "2.0.0-rc.1", false, true},
+		{"^2.0.0-beta", "2.0.0-alpha", false, false},
+		{"^2.0.0-beta", "2.1.0-beta", false, false},
+		{"^2.0.0-beta", "2.1.0-beta", true, true},
+		{"^2.0.0-beta", "2.0.0", false, true},
+		{"~1.3.0-beta.0", "1.3.0-beta.1", false, true},
+		{">=1.0.0-rc.1 <1.0.0", "1.0.0-rc.2", false, true},
+		{"1.0.0-rc.1", "1.0.0-rc.1", false, true},
+		{"1.0.0-rc.1", "1.0.0-rc.2", false, false},
+
+		// includePrerelease does not widen the range
+		{"^1.2", "2.0.0-beta", true, false},
+		{"~2.0.3", "2.1.0-alpha", true, false},
+	}
+	for _, tt := range tests {
+		constraint, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		version, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.version, err)
+		}
+		if got := constraint.check(version, tt.includePrerelease); got != tt.want {
+			t.Errorf("%q.check(%s, %v) = %v, want %v", tt.constraint, tt.version, tt.includePrerelease, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintLatest(t *testing.T) {
+	versions := []string{
+		"0.9.0", "1.0.0", "1.2.0", "1.2.5", "1.3.0-beta.1", "1.9.1", "2.0.0-rc.1",
+		"2.0.0", "2.0.3", "2.0.7", "2.1.0", "v3.0.0", "3.1.0-alpha", "not-a-version",
+	}
+	tests := []struct {
+		constraint        string
+		includePrerelease bool
+		want              string
+	}{
+		{"^1.2", false, "1.9.1"},
+		{"~2.0.3", false, "2.0.7"},
+		{">=1.0 <2.0", false, "1.9.1"},
+		{"latest", false, "v3.0.0"},
+		{"latest", true, "3.1.0-alpha"},
+		{"1.2", false, "1.2.5"},
+		{"1.x || 2.0.x", false, "2.0.7"},
+		{"1.0 - 1.2", false, "1.2.5"},
+		{"^2.0.0-rc.0", false, "2.1.0"},
+		{"<2.0.0-rc.2", false, "2.0.0-rc.1"},
+		{">=1.3.0-0 <1.4", false, "1.3.0-beta.1"},
+		{"<2", true, "1.9.1"},
+		{"^4", false, ""},
+	}
+	for _, tt := range tests {
+		constraint, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q): %v", tt.constraint, err)
+		}
+		got, ok := constraint.Latest(versions, tt.includePrerelease)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("%q.Latest(includePrerelease=%v) = %q, %v; want %q", tt.constraint, tt.includePrerelease, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, in := range []string{"^1.2.3.4", "abc", ">=1.x.3", "1.2-beta", "01.2", ">>1.0", "1.0 - x.y"} {
+		if _, err := ParseConstraint(in); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded", in)
+		}
+	}
+}
+
+func TestConstraintExact(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"=v1.2.3", "1.2.3"},
+		{"2.0.0-beta.1", "2.0.0-beta.1"},
+		{"1.2", ""},
+		{"^1.2.3", ""},
+		{"1.2.3 || 1.2.4", ""},
+		{"latest", ""},
+	}
+	for _, tt := range tests {
+		constraint, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q): %v", tt.constraint, err)
+		}
+		got, ok := constraint.exact()
+		if ok != (tt.want != "") || ok && got.String() != tt.want {
+			t.Errorf("%q.exact() = %v, %v; want %q", tt.constraint, got, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxFileSize is the largest file SubmitDirectory uploads when no limit is set
+	DefaultMaxFileSize = 1 << 20
+
+	// DefaultMaxBatchBytes caps the file content sent in one submission request
+	DefaultMaxBatchBytes = 4 << 20
+
+	// DefaultMaxBatchFiles caps the number of files sent in one submission request
+	DefaultMaxBatchFiles = 200
+)
+
+// binarySniffLength is how much of a file is checked for NUL bytes, as git does
+const binarySniffLength = 8000
+
+// Reasons reported in SkippedFile
+const (
+	SkipReasonBinary   = "binary"
+	SkipReasonTooLarge = "too_large"
+)
+
+// SubmitOptions configures SubmitDirectory
+type SubmitOptions struct {
+	// Globs a file must match to be submitted; empty submits every file.
+	// Patterns without a slash match the file name, "**" matches any directories.
+	Include []string
+
+	// Globs excluding files and directories, applied after .gitignore and .tavoignore
+	Exclude []string
+
+	// Files larger than this are skipped
+	MaxFileSize int64
+
+	// Limits of a single submission request; larger trees are sent in several batches
+	MaxBatchBytes int64
+	MaxBatchFiles int
+
+	// Repository metadata sent with the files. Branch and CommitSHA default to
+	// the state of the local git repository, RepositoryName to its directory name.
+	RepositoryName string
+	Branch         string
+	CommitSHA      string
+
+	// Scan configuration sent with every batch
+	ScanConfig map[string]interface{}
+}
+
+// SourceFile is a file collected for submission
+type SourceFile struct {
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	Language string `json:"language,omitempty"`
+	Size     int64  `json:"size"`
+
+	// Set for diff submissions: the lines changed relative to the base ref and,
+	// for a renamed file, its path at the base ref
+	ChangedLines []LineRange `json:"changed_lines,omitempty"`
+	PreviousPath string      `json:"previous_path,omitempty"`
+}
+
+// SkippedFile is a file left out of a submission and why
+type SkippedFile struct {
+	Path   string
+	Reason string
+}
+
+// SubmissionBatch is one submission request sent by SubmitDirectory
+type SubmissionBatch struct {
+	// Paths of the files in the batch
+	Files []string
+	Bytes int64
+
+	// Scan started by the batch, if the response reported one
+	ScanID string
+	Result interface{}
+}
+
+// DirectorySubmission describes what SubmitDirectory sent
+type DirectorySubmission struct {
+	RepositoryName string
+	Branch         string
+	CommitSHA      string
+	Batches        []SubmissionBatch
+	Skipped        []SkippedFile
+}
+
+// ScanIDs returns the scans started by the submission's batches
+func (s *DirectorySubmission) ScanIDs() []string {
+	var ids []string
+	for _, batch := range s.Batches {
+		if batch.ScanID != "" {
+			ids = append(ids, batch.ScanID)
+		}
+	}
+	return ids
+}
+
+// withDefaults returns a copy of opts, which may be nil, with unset limits filled in
+func (opts *SubmitOptions) withDefaults() *SubmitOptions {
+	options := &SubmitOptions{}
+	if opts != nil {
+		copied := *opts
+		options = &copied
+	}
+	if options.MaxFileSize <= 0 {
+		options.MaxFileSize = DefaultMaxFileSize
+	}
+	if options.MaxBatchBytes <= 0 {
+		options.MaxBatchBytes = DefaultMaxBatchBytes
+	}
+	if options.MaxBatchFiles <= 0 {
+		options.MaxBatchFiles = DefaultMaxBatchFiles
+	}
+	options.MaxFileSize = min(options.MaxFileSize, options.MaxBatchBytes)
+	return options
+}
+
+// CollectFiles walks root and returns the text files SubmitDirectory would
+// send, in lexical order, along with the files skipped as binary or too large.
+// Files excluded by ignore files or globs are left out silently.
+func CollectFiles(ctx context.Context, root string, opts *SubmitOptions) ([]SourceFile, []SkippedFile, error) {
+	options := opts.withDefaults()
+
+	var files []SourceFile
+	var skipped []SkippedFile
+	var rules ignoreRules
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == "." {
+				return rules.load(path, "", ignoreFileNames)
+			}
+			if d.Name() == ".git" || rules.ignored(rel, true) || matchAnyGlob(options.Exclude, rel) {
+				return filepath.SkipDir
+			}
+			return rules.load(path, rel, ignoreFileNames)
+		}
+
+		if !d.Type().IsRegular() || rules.ignored(rel, false) || matchAnyGlob(options.Exclude, rel) {
+			return nil
+		}
+		if len(options.Include) > 0 && !matchAnyGlob(options.Include, rel) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > options.MaxFileSize {
+			skipped = append(skipped, SkippedFile{Path: rel, Reason: SkipReasonTooLarge})
+			return nil
+		}
+
+		content, err := readTextFile(path)
+		if err != nil {
+			return err
+		}
+		if content == nil {
+			skipped = append(skipped, SkippedFile{Path: rel, Reason: SkipReasonBinary})
+			return nil
+		}
+		files = append(files, SourceFile{
+			Path:     rel,
+			Content:  string(content),
+			Language: DetectLanguage(rel),
+			Size:     int64(len(content)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, skipped, nil
+}
+
+// readTextFile returns the content of a text file, or nil for a binary file
+func readTextFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	if !isText(content) {
+		return nil, nil
+	}
+	if content == nil {
+		content = []byte{}
+	}
+	return content, nil
+}
+
+// isText reports whether content looks like UTF-8 text rather than binary data
+func isText(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), binarySniffLength)], 0) < 0 && utf8.Valid(content)
+}
+
+// batchFiles splits files into batches within the size and count limits
+func batchFiles(files []SourceFile, maxBytes int64, maxFiles int) [][]SourceFile {
+	var batches [][]SourceFile
+	var current []SourceFile
+	var size int64
+	for _, file := range files {
+		if len(current) > 0 && (size+file.Size > maxBytes || len(current) >= maxFiles) {
+			batches = append(batches, current)
+			current, size = nil, 0
+		}
+		current = append(current, file)
+		size += file.Size
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// SubmitDirectory collects the source files under root and submits them for
+// scanning in one or more size-capped batches. opts may be nil. On error the
+// returned submission lists the batches that were already sent.
+func (c *CodeSubmissionClient) SubmitDirectory(ctx context.Context, root string, opts *SubmitOptions) (*DirectorySubmission, error) {
+	options := opts.withDefaults()
+
+	files, skipped, err := CollectFiles(ctx, root, options)
+	if err != nil {
+		return nil, err
+	}
+	submission := &DirectorySubmission{Skipped: skipped}
+	if err := c.describeRepository(ctx, root, options, submission); err != nil {
+		return nil, err
+	}
+	return submission, c.submitFiles(ctx, files, options, submission)
+}
+
+// describeRepository fills in the repository name, branch and commit of a submission
+func (c *CodeSubmissionClient) describeRepository(ctx context.Context, root string, options *SubmitOptions, submission *DirectorySubmission) error {
+	submission.RepositoryName = options.RepositoryName
+	submission.Branch = options.Branch
+	submission.CommitSHA = options.CommitSHA
+
+	if submission.Branch == "" || submission.CommitSHA == "" {
+		branch, commitSHA := gitHead(ctx, root)
+		if submission.Branch == "" {
+			submission.Branch = branch
+		}
+		if submission.CommitSHA == "" {
+			submission.CommitSHA = commitSHA
+		}
+	}
+	if submission.RepositoryName == "" {
+		dir := root
+		if toplevel, err := runGit(ctx, root, "rev-parse", "--show-toplevel"); err == nil {
+			dir = toplevel
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		submission.RepositoryName = filepath.Base(abs)
+	}
+	return nil
+}
+
+// submitFiles sends files in batches and records each batch on submission
+func (c *CodeSubmissionClient) submitFiles(ctx context.Context, files []SourceFile, options *SubmitOptions, submission *DirectorySubmission) error {
+	var scanConfig *interface{}
+	if options.ScanConfig != nil {
+		var config interface{} = options.ScanConfig
+		scanConfig = &config
+	}
+
+	batches := batchFiles(files, options.MaxBatchBytes, options.MaxBatchFiles)
+	for i, batch := range batches {
+		payload := make([]interface{}, len(batch))
+		record := SubmissionBatch{Files: make([]string, len(batch))}
+		for j, file := range batch {
+			payload[j] = file
+			record.Files[j] = file.Path
+			record.Bytes += file.Size
+		}
+
+		result, err := c.PostsubmitcodeWithContext(ctx, &payload, scanConfig,
+			optionalString(submission.RepositoryName), optionalString(submission.Branch), optionalString(submission.CommitSHA))
+		if err != nil {
+			return fmt.Errorf("submit batch %d of %d: %w", i+1, len(batches), err)
+		}
+		record.Result = result
+		record.ScanID = submittedScanID(result)
+		submission.Batches = append(submission.Batches, record)
+	}
+	return nil
+}
+
+// submittedScanID extracts the scan ID from a submission response
+func submittedScanID(result interface{}) string {
+	var response struct {
+		ScanID string `json:"scan_id"`
+		ID     string `json:"id"`
+	}
+	if err := decodeResult(result, &response); err != nil {
+		return ""
+	}
+	if response.ScanID != "" {
+		return response.ScanID
+	}
+	return response.ID
+}
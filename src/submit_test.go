@@ -0,0 +1,205 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// writeTree creates files under a temporary directory and returns it
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// sourceTree is a repository with ignore files, binary and oversized files
+var sourceTree = map[string]string{
+	".gitignore":          "*.log\nbuild/\n!keep.log\n",
+	"main.go":             "package main\n",
+	"Dockerfile":          "FROM scratch\n",
+	"debug.log":           "ignored\n",
+	"keep.log":            "kept\n",
+	"build/app.js":        "ignored\n",
+	"web/app.ts":          "export {}\n",
+	"web/.tavoignore":     "/generated\n",
+	"web/generated/x.ts":  "ignored\n",
+	"web/src/generated":   "a file, not the ignored directory\n",
+	"scripts/run.py":      "print()\n",
+	"testdata/golden.go":  "package testdata\n",
+	"assets/logo.png":     "\x89PNG\r\n\x1a\n\x00\x00",
+	"assets/latin1.txt":   "caf\xe9\n",
+	"big/data.sql":        strings.Repeat("x", 100),
+	".git/config":         "[core]\n",
+	"node_modules/a/a.js": "ignored by the Exclude glob\n",
+}
+
+func TestCollectFiles(t *testing.T) {
+	root := writeTree(t, sourceTree)
+
+	files, skipped, err := CollectFiles(context.Background(), root, &SubmitOptions{
+		Exclude:     []string{"testdata/**", "node_modules"},
+		MaxFileSize: 50,
+	})
+	if err != nil {
+		t.Fatalf("CollectFiles: %v", err)
+	}
+
+	var paths []string
+	languages := map[string]string{}
+	for _, file := range files {
+		paths = append(paths, file.Path)
+		languages[file.Path] = file.Language
+		if want := sourceTree[file.Path]; file.Content != want || file.Size != int64(len(want)) {
+			t.Errorf("%s: content, size = %q, %d", file.Path, file.Content, file.Size)
+		}
+	}
+	wantPaths := []string{".gitignore", "Dockerfile", "keep.log", "main.go", "scripts/run.py", "web/.tavoignore", "web/app.ts", "web/src/generated"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("paths = %q, want %q", paths, wantPaths)
+	}
+	wantLanguages := map[string]string{"main.go": "go", "Dockerfile": "dockerfile", "scripts/run.py": "python", "web/app.ts": "typescript", "keep.log": ""}
+	for path, want := range wantLanguages {
+		if languages[path] != want {
+			t.Errorf("language of %s = %q, want %q", path, languages[path], want)
+		}
+	}
+
+	wantSkipped := []SkippedFile{
+		{Path: "assets/latin1.txt", Reason: SkipReasonBinary},
+		{Path: "assets/logo.png", Reason: SkipReasonBinary},
+		{Path: "big/data.sql", Reason: SkipReasonTooLarge},
+	}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("skipped = %+v, want %+v", skipped, wantSkipped)
+	}
+}
+
+func TestCollectFilesInclude(t *testing.T) {
+	root := writeTree(t, sourceTree)
+	files, _, err := CollectFiles(context.Background(), root, &SubmitOptions{Include: []string{"*.go", "web/**/*.ts"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	// Include does not override ignore files: web/generated stays excluded
+	if want := []string{"main.go", "testdata/golden.go", "web/app.ts"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+}
+
+func TestCollectFilesCancelled(t *testing.T) {
+	root := writeTree(t, sourceTree)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := CollectFiles(ctx, root, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if _, _, err := CollectFiles(context.Background(), filepath.Join(root, "missing"), nil); err == nil {
+		t.Error("CollectFiles accepted a missing root")
+	}
+}
+
+func TestBatchFiles(t *testing.T) {
+	file := func(name string, size int64) SourceFile { return SourceFile{Path: name, Size: size} }
+	tests := []struct {
+		name     string
+		files    []SourceFile
+		maxBytes int64
+		maxFiles int
+		want     [][]string
+	}{
+		{"empty", nil, 10, 10, nil},
+		{"one batch", []SourceFile{file("a", 3), file("b", 3)}, 10, 10, [][]string{{"a", "b"}}},
+		{"byte limit", []SourceFile{file("a", 6), file("b", 4), file("c", 1)}, 10, 10, [][]string{{"a", "b"}, {"c"}}},
+		{"file limit", []SourceFile{file("a", 1), file("b", 1), file("c", 1)}, 10, 2, [][]string{{"a", "b"}, {"c"}}},
+		{"file at the byte limit", []SourceFile{file("a", 1), file("b", 10)}, 10, 10, [][]string{{"a"}, {"b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, batch := range batchFiles(tt.files, tt.maxBytes, tt.maxFiles) {
+				var names []string
+				for _, file := range batch {
+					names = append(names, file.Path)
+				}
+				got = append(got, names)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("batches = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitDirectoryBatches(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package b\n",
+		"c.py": "print()\n",
+	})
+
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, body)
+		n := len(requests)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"scan_id": "scan-%d"}`, n)
+	})
+
+	submission, err := client.CodeSubmission().SubmitDirectory(context.Background(), root, &SubmitOptions{
+		MaxBatchFiles:  2,
+		RepositoryName: "demo",
+		Branch:         "main",
+		CommitSHA:      "abc123",
+		ScanConfig:     map[string]interface{}{"rules": "default"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitDirectory: %v", err)
+	}
+	if got := submission.ScanIDs(); !reflect.DeepEqual(got, []string{"scan-1", "scan-2"}) {
+		t.Errorf("ScanIDs = %q", got)
+	}
+	if len(submission.Batches) != 2 || !reflect.DeepEqual(submission.Batches[0].Files, []string{"a.go", "b.go"}) || submission.Batches[1].Bytes != 8 {
+		t.Errorf("batches = %+v", submission.Batches)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, body := range requests {
+		if body["repository_name"] != "demo" || body["branch"] != "main" || body["commit_sha"] != "abc123" || body["scan_config"] == nil {
+			t.Errorf("request = %v, want the repository metadata and scan config", body)
+		}
+	}
+	files, _ := requests[1]["files"].([]interface{})
+	if len(files) != 1 {
+		t.Fatalf("second batch files = %v", requests[1]["files"])
+	}
+	if file := files[0].(map[string]interface{}); file["path"] != "c.py" || file["language"] != "python" || file["content"] != "print()\n" {
+		t.Errorf("submitted file = %v", file)
+	}
+}
@@ -2,17 +2,33 @@
 package tavo
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Client is the main client for interacting with Tavo AI API
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient  *http.Client
+	baseURL     string
+	apiPath     string
+	userAgent   string
+	retryPolicy *RetryPolicy
 
 	// Authentication
-	apiKey      string
-	deviceToken string
+	authMu          sync.RWMutex
+	refreshMu       sync.Mutex
+	apiKey          string
+	deviceToken     string
+	refreshToken    string
+	tokenExpiry     time.Time
+	credentialStore CredentialStore
+	profile         string
+	reauthenticator Reauthenticator
+	credentialErr   error
 
 	deviceAuth *DeviceAuthClient
 	scans *ScansClient
@@ -43,20 +59,37 @@ type Client struct {
 }
 
 // NewClient creates a new Tavo API client
-func NewClient(apiKey, deviceToken, baseUrl string) *Client {
+func NewClient(apiKey, deviceToken, baseUrl string, opts ...Option) *Client {
 	if baseUrl == "" {
-		baseUrl = "https://api.tavo.ai"
+		baseUrl = DefaultBaseURL
 	}
 
-	httpClient := &http.Client{}
-
 	client := &Client{
-		httpClient:  httpClient,
-		baseURL:     baseUrl,
+		baseURL:     strings.TrimSuffix(baseUrl, "/"),
 		apiKey:      apiKey,
 		deviceToken: deviceToken,
 	}
 
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.apply(client)
+
+	if apiKey == "" && deviceToken == "" {
+		chain := CredentialChain{EnvCredentials()}
+		if client.credentialStore != nil {
+			chain = append(chain, StoredCredentials(client.credentialStore, client.profile, client.baseURL))
+		}
+		creds, err := chain.Resolve()
+		switch {
+		case err == nil:
+			client.setCredentials(creds)
+		case !errors.Is(err, ErrCredentialsNotFound):
+			client.credentialErr = fmt.Errorf("tavo: load stored credentials: %w", err)
+		}
+	}
+
 	// Initialize endpoint clients
 		client.deviceAuth = &DeviceAuthClient{client: client}
 		client.scans = &ScansClient{client: client}
@@ -90,12 +123,140 @@ func NewClient(apiKey, deviceToken, baseUrl string) *Client {
 
 // SetAPIKey updates the API key for authentication
 func (c *Client) SetAPIKey(apiKey string) {
-	c.apiKey = apiKey
-	c.deviceToken = ""
+	c.setCredentials(&Credentials{APIKey: apiKey})
 }
 
 // SetDeviceToken updates the device token for authentication
 func (c *Client) SetDeviceToken(deviceToken string) {
-	c.deviceToken = deviceToken
-	c.apiKey = ""
+	c.setCredentials(&Credentials{DeviceToken: deviceToken})
+}
+
+// DeviceAuth returns the client for device_auth API calls
+func (c *Client) DeviceAuth() *DeviceAuthClient {
+	return c.deviceAuth
+}
+
+// Scans returns the client for scans API calls
+func (c *Client) Scans() *ScansClient {
+	return c.scans
+}
+
+// ScanManagement returns the client for scan_management API calls
+func (c *Client) ScanManagement() *ScanManagementClient {
+	return c.scanManagement
+}
+
+// ScanTools returns the client for scan_tools API calls
+func (c *Client) ScanTools() *ScanToolsClient {
+	return c.scanTools
+}
+
+// ScanRules returns the client for scan_rules API calls
+func (c *Client) ScanRules() *ScanRulesClient {
+	return c.scanRules
+}
+
+// ScanSchedules returns the client for scan_schedules API calls
+func (c *Client) ScanSchedules() *ScanSchedulesClient {
+	return c.scanSchedules
+}
+
+// ScanBulkOperations returns the client for scan_bulk_operations API calls
+func (c *Client) ScanBulkOperations() *ScanBulkOperationsClient {
+	return c.scanBulkOperations
+}
+
+// ScannerIntegration returns the client for scanner_integration API calls
+func (c *Client) ScannerIntegration() *ScannerIntegrationClient {
+	return c.scannerIntegration
+}
+
+// AiAnalysis returns the client for ai_analysis API calls
+func (c *Client) AiAnalysis() *AiAnalysisClient {
+	return c.aiAnalysis
+}
+
+// AiAnalysisCore returns the client for ai_analysis_core API calls
+func (c *Client) AiAnalysisCore() *AiAnalysisCoreClient {
+	return c.aiAnalysisCore
+}
+
+// AiBulkOperations returns the client for ai_bulk_operations API calls
+func (c *Client) AiBulkOperations() *AiBulkOperationsClient {
+	return c.aiBulkOperations
+}
+
+// AiPerformanceQuality returns the client for ai_performance_quality API calls
+func (c *Client) AiPerformanceQuality() *AiPerformanceQualityClient {
+	return c.aiPerformanceQuality
+}
+
+// AiResultsExport returns the client for ai_results_export API calls
+func (c *Client) AiResultsExport() *AiResultsExportClient {
+	return c.aiResultsExport
+}
+
+// AiRiskCompliance returns the client for ai_risk_compliance API calls
+func (c *Client) AiRiskCompliance() *AiRiskComplianceClient {
+	return c.aiRiskCompliance
+}
+
+// Registry returns the client for registry API calls
+func (c *Client) Registry() *RegistryClient {
+	return c.registry
+}
+
+// PluginExecution returns the client for plugin_execution API calls
+func (c *Client) PluginExecution() *PluginExecutionClient {
+	return c.pluginExecution
+}
+
+// PluginMarketplace returns the client for plugin_marketplace API calls
+func (c *Client) PluginMarketplace() *PluginMarketplaceClient {
+	return c.pluginMarketplace
+}
+
+// Rules returns the client for rules API calls
+func (c *Client) Rules() *RulesClient {
+	return c.rules
+}
+
+// CodeSubmission returns the client for code_submission API calls
+func (c *Client) CodeSubmission() *CodeSubmissionClient {
+	return c.codeSubmission
+}
+
+// Repositories returns the client for repositories API calls
+func (c *Client) Repositories() *RepositoriesClient {
+	return c.repositories
+}
+
+// RepositoryConnections returns the client for repository_connections API calls
+func (c *Client) RepositoryConnections() *RepositoryConnectionsClient {
+	return c.repositoryConnections
+}
+
+// RepositoryProviders returns the client for repository_providers API calls
+func (c *Client) RepositoryProviders() *RepositoryProvidersClient {
+	return c.repositoryProviders
+}
+
+// RepositoryWebhooks returns the client for repository_webhooks API calls
+func (c *Client) RepositoryWebhooks() *RepositoryWebhooksClient {
+	return c.repositoryWebhooks
+}
+
+// Jobs returns the client for jobs API calls
+func (c *Client) Jobs() *JobsClient {
+	return c.jobs
+}
+
+// Health returns the client for health API calls
+func (c *Client) Health() *HealthClient {
+	return c.health
+}
+
+// Websockets returns the client for websockets API calls
+func (c *Client) Websockets() *WebsocketsClient {
+	return c.websockets
 }
@@ -0,0 +1,46 @@
+package tavo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClientExposesEverySubClient(t *testing.T) {
+	client := NewClient("test-key", "", "")
+	value := reflect.ValueOf(client)
+	fields := value.Elem()
+
+	accessors := map[reflect.Type]reflect.Method{}
+	for i := 0; i < value.NumMethod(); i++ {
+		method := value.Type().Method(i)
+		if method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+			accessors[method.Type.Out(0)] = method
+		}
+	}
+
+	found := 0
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Type().Field(i)
+		if field.Type.Kind() != reflect.Pointer || !strings.HasSuffix(field.Type.Elem().Name(), "Client") ||
+			field.Type.Elem().PkgPath() != fields.Type().PkgPath() {
+			continue
+		}
+		found++
+
+		method, ok := accessors[field.Type]
+		if !ok {
+			t.Errorf("field %s has no exported accessor returning %s", field.Name, field.Type)
+			continue
+		}
+		got := method.Func.Call([]reflect.Value{value})[0]
+		if got.IsNil() {
+			t.Errorf("%s() returned nil", method.Name)
+		} else if got.Pointer() != fields.Field(i).Pointer() {
+			t.Errorf("%s() does not return the %s field", method.Name, field.Name)
+		}
+	}
+	if found == 0 {
+		t.Fatal("found no sub-client fields")
+	}
+}
@@ -0,0 +1,85 @@
+package tavo
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JobState is the lifecycle state of a background job
+type JobState string
+
+const (
+	JobStatePending   JobState = "pending"
+	JobStateQueued    JobState = "queued"
+	JobStateRunning   JobState = "running"
+	JobStateCompleted JobState = "completed"
+	JobStateFailed    JobState = "failed"
+	JobStateCancelled JobState = "cancelled"
+)
+
+// IsTerminal reports whether a job in this state will not change any more
+func (s JobState) IsTerminal() bool {
+	switch s {
+	case JobStateCompleted, JobStateFailed, JobStateCancelled:
+		return true
+	}
+	return false
+}
+
+// jobStateAliases maps the other spellings used by job types onto the JobState constants
+var jobStateAliases = map[string]JobState{
+	"success":     JobStateCompleted,
+	"succeeded":   JobStateCompleted,
+	"done":        JobStateCompleted,
+	"finished":    JobStateCompleted,
+	"error":       JobStateFailed,
+	"errored":     JobStateFailed,
+	"canceled":    JobStateCancelled,
+	"in_progress": JobStateRunning,
+	"processing":  JobStateRunning,
+	"started":     JobStateRunning,
+}
+
+// parseJobState normalizes a job status reported by the API
+func parseJobState(value string) JobState {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if state, ok := jobStateAliases[value]; ok {
+		return state
+	}
+	return JobState(value)
+}
+
+// JobStatus is the status of a background job as returned by the jobs API
+type JobStatus struct {
+	JobID     string      `json:"job_id"`
+	JobType   string      `json:"job_type,omitempty"`
+	Status    JobState    `json:"status"`
+	Progress  float64     `json:"progress,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Result    interface{} `json:"result,omitempty"`
+	CreatedAt Timestamp   `json:"created_at"`
+	UpdatedAt Timestamp   `json:"updated_at"`
+}
+
+// UnmarshalJSON also accepts "id" and "state" and normalizes the status
+func (s *JobStatus) UnmarshalJSON(data []byte) error {
+	type plain JobStatus
+	var envelope struct {
+		plain
+		ID    string `json:"id"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	*s = JobStatus(envelope.plain)
+	if s.JobID == "" {
+		s.JobID = envelope.ID
+	}
+	if s.Status == "" {
+		s.Status = JobState(envelope.State)
+	}
+	s.Status = parseJobState(string(s.Status))
+	return nil
+}
@@ -0,0 +1,199 @@
+package tavo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ScanStatus is the lifecycle state of a scan
+type ScanStatus string
+
+const (
+	ScanStatusPending   ScanStatus = "pending"
+	ScanStatusQueued    ScanStatus = "queued"
+	ScanStatusRunning   ScanStatus = "running"
+	ScanStatusCompleted ScanStatus = "completed"
+	ScanStatusFailed    ScanStatus = "failed"
+	ScanStatusCancelled ScanStatus = "cancelled"
+)
+
+// IsTerminal reports whether a scan in this state will not change any more
+func (s ScanStatus) IsTerminal() bool {
+	switch s {
+	case ScanStatusCompleted, ScanStatusFailed, ScanStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// Timestamp is a time.Time that also accepts the timezone-less ISO 8601
+// timestamps the API emits
+type Timestamp struct {
+	time.Time
+}
+
+// timestampLayouts are tried in order when decoding a Timestamp
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	var lastErr error
+	for _, layout := range timestampLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+		lastErr = err
+	}
+	return lastErr
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+// ScanCreateRequest is the payload for creating a scan
+type ScanCreateRequest struct {
+	Name           string                 `json:"name,omitempty"`
+	ScanType       string                 `json:"scan_type,omitempty"`
+	RepositoryID   string                 `json:"repository_id,omitempty"`
+	RepositoryURL  string                 `json:"repository_url,omitempty"`
+	Target         string                 `json:"target,omitempty"`
+	Branch         string                 `json:"branch,omitempty"`
+	CommitSHA      string                 `json:"commit_sha,omitempty"`
+	OrganizationID string                 `json:"organization_id,omitempty"`
+	Tools          []string               `json:"tools,omitempty"`
+	Rules          []string               `json:"rules,omitempty"`
+	Plugins        []string               `json:"plugins,omitempty"`
+	Configuration  map[string]interface{} `json:"configuration,omitempty"`
+}
+
+// Scan is a scan as returned by the API
+type Scan struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name,omitempty"`
+	Status         ScanStatus             `json:"status"`
+	ScanType       string                 `json:"scan_type,omitempty"`
+	Progress       float64                `json:"progress,omitempty"`
+	RepositoryID   string                 `json:"repository_id,omitempty"`
+	RepositoryURL  string                 `json:"repository_url,omitempty"`
+	Target         string                 `json:"target,omitempty"`
+	Branch         string                 `json:"branch,omitempty"`
+	CommitSHA      string                 `json:"commit_sha,omitempty"`
+	OrganizationID string                 `json:"organization_id,omitempty"`
+	UserID         string                 `json:"user_id,omitempty"`
+	TotalFindings  int                    `json:"total_findings,omitempty"`
+	ErrorMessage   string                 `json:"error_message,omitempty"`
+	Configuration  map[string]interface{} `json:"configuration,omitempty"`
+	CreatedAt      Timestamp              `json:"created_at"`
+	UpdatedAt      Timestamp              `json:"updated_at"`
+	StartedAt      *Timestamp             `json:"started_at,omitempty"`
+	CompletedAt    *Timestamp             `json:"completed_at,omitempty"`
+}
+
+// Finding is a single issue reported by a scan
+type Finding struct {
+	ID          string                 `json:"id,omitempty"`
+	ScanID      string                 `json:"scan_id,omitempty"`
+	RuleID      string                 `json:"rule_id"`
+	RuleName    string                 `json:"rule_name,omitempty"`
+	RuleType    string                 `json:"rule_type,omitempty"`
+	Tool        string                 `json:"tool,omitempty"`
+	Severity    Severity               `json:"severity"`
+	Title       string                 `json:"title,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	FilePath    string                 `json:"file_path"`
+	LineNumber  int                    `json:"line_number,omitempty"`
+	EndLine     int                    `json:"end_line,omitempty"`
+	Column      int                    `json:"column,omitempty"`
+	Snippet     string                 `json:"code_snippet,omitempty"`
+	CWEID       string                 `json:"cwe_id,omitempty"`
+	Remediation string                 `json:"remediation,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   Timestamp              `json:"created_at"`
+}
+
+// ScanSummary counts a scan's findings by severity
+type ScanSummary struct {
+	Total    int `json:"total"`
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Info     int `json:"info"`
+}
+
+// ScanResult holds the findings of a scan
+type ScanResult struct {
+	ScanID   string       `json:"scan_id,omitempty"`
+	Status   ScanStatus   `json:"status,omitempty"`
+	Findings []Finding    `json:"results"`
+	Summary  *ScanSummary `json:"summary,omitempty"`
+	Total    int          `json:"total,omitempty"`
+}
+
+// UnmarshalJSON accepts either a result envelope or a bare array of findings
+func (r *ScanResult) UnmarshalJSON(data []byte) error {
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		r.Findings = nil
+		if err := json.Unmarshal(data, &r.Findings); err != nil {
+			return err
+		}
+		r.Total = len(r.Findings)
+		return nil
+	}
+	type plain ScanResult
+	var envelope struct {
+		plain
+		Findings []Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	*r = ScanResult(envelope.plain)
+	if r.Findings == nil {
+		r.Findings = envelope.Findings
+	}
+	return nil
+}
+
+// ScanCancelResponse is returned when a scan is cancelled
+type ScanCancelResponse struct {
+	ScanID  string     `json:"scan_id,omitempty"`
+	Status  ScanStatus `json:"status,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
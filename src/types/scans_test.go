@@ -0,0 +1,183 @@
+package tavo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readFixture returns the content of a file in testdata
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// roundTrip decodes data, encodes the result and decodes that encoding into a
+// second value of the same type. The two encodings must be identical.
+func roundTrip[T any](t *testing.T, data []byte) (first, second T) {
+	t.Helper()
+	if err := json.Unmarshal(data, &first); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+	encoded, err := json.Marshal(first)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if err := json.Unmarshal(encoded, &second); err != nil {
+		t.Fatalf("decode %s: %v", encoded, err)
+	}
+	reencoded, err := json.Marshal(second)
+	if err != nil {
+		t.Fatalf("encode again: %v", err)
+	}
+	if string(encoded) != string(reencoded) {
+		t.Errorf("encoding changed after a round trip:\n%s\n%s", encoded, reencoded)
+	}
+	return first, second
+}
+
+func TestScanRoundTrip(t *testing.T) {
+	scan, decoded := roundTrip[Scan](t, readFixture(t, "scan.json"))
+
+	for _, s := range []Scan{scan, decoded} {
+		if s.ID != "scan_01HX3K9" || s.Status != ScanStatusRunning || s.Progress != 42.5 || s.TotalFindings != 2 {
+			t.Errorf("scan = %+v", s)
+		}
+		if want := time.Date(2024, 5, 1, 10, 0, 0, 123456000, time.UTC); !s.CreatedAt.Equal(want) {
+			t.Errorf("CreatedAt = %v, want %v", s.CreatedAt, want)
+		}
+		if want := time.Date(2024, 5, 1, 10, 1, 0, 0, time.UTC); s.StartedAt == nil || !s.StartedAt.Equal(want) {
+			t.Errorf("StartedAt = %v, want %v", s.StartedAt, want)
+		}
+		if s.CompletedAt != nil {
+			t.Errorf("CompletedAt = %v, want nil", s.CompletedAt)
+		}
+		if tools, _ := s.Configuration["tools"].([]interface{}); len(tools) != 1 || tools[0] != "semgrep" {
+			t.Errorf("Configuration = %v", s.Configuration)
+		}
+	}
+}
+
+func TestScanResultRoundTrip(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		scanID   string
+		total    int
+		findings []string
+	}{
+		{"scan_result_envelope.json", "scan_01HX3K9", 2, []string{"python.sql-injection", "generic.secret"}},
+		{"scan_result_findings_envelope.json", "scan_01HX3K9", 1, []string{"generic.secret"}},
+		{"scan_result_array.json", "", 2, []string{"python.sql-injection", "generic.secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			result, decoded := roundTrip[ScanResult](t, readFixture(t, tt.fixture))
+
+			for _, r := range []ScanResult{result, decoded} {
+				if r.ScanID != tt.scanID || r.Total != tt.total || len(r.Findings) != len(tt.findings) {
+					t.Fatalf("result = %+v", r)
+				}
+				for i, ruleID := range tt.findings {
+					if r.Findings[i].RuleID != ruleID {
+						t.Errorf("Findings[%d].RuleID = %q, want %q", i, r.Findings[i].RuleID, ruleID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestScanResultEnvelopeFields(t *testing.T) {
+	var result ScanResult
+	if err := json.Unmarshal(readFixture(t, "scan_result_envelope.json"), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != ScanStatusCompleted || result.Summary == nil || result.Summary.Critical != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	finding := result.Findings[0]
+	if finding.Severity != SeverityHigh || finding.LineNumber != 12 || finding.EndLine != 14 ||
+		finding.Snippet != "cursor.execute(query % name)" || finding.CWEID != "CWE-89" {
+		t.Errorf("finding = %+v", finding)
+	}
+	if want := time.Date(2024, 5, 1, 10, 6, 0, 0, time.UTC); !finding.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", finding.CreatedAt, want)
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		out  string
+	}{
+		{`"2024-05-01T10:00:00Z"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), `"2024-05-01T10:00:00Z"`},
+		{`"2024-05-01T12:00:00+02:00"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), `"2024-05-01T12:00:00+02:00"`},
+		{`"2024-05-01T10:00:00.5"`, time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC), `"2024-05-01T10:00:00.5Z"`},
+		{`"2024-05-01 10:00:00"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), `"2024-05-01T10:00:00Z"`},
+		{`"2024-05-01"`, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), `"2024-05-01T00:00:00Z"`},
+		{`null`, time.Time{}, `null`},
+		{`""`, time.Time{}, `null`},
+	}
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("decode %s: %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("decode %s = %v, want %v", tt.in, ts.Time, tt.want)
+		}
+		out, err := json.Marshal(ts)
+		if err != nil || string(out) != tt.out {
+			t.Errorf("encode %s = %s, %v; want %s", tt.in, out, err, tt.out)
+		}
+	}
+
+	for _, in := range []string{`"yesterday"`, `"2024-13-01"`, `42`} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err == nil {
+			t.Errorf("decode %s = %v, want an error", in, ts.Time)
+		}
+	}
+}
+
+func TestScanStatusJSON(t *testing.T) {
+	tests := []struct {
+		status   ScanStatus
+		terminal bool
+	}{
+		{ScanStatusPending, false},
+		{ScanStatusQueued, false},
+		{ScanStatusRunning, false},
+		{ScanStatusCompleted, true},
+		{ScanStatusFailed, true},
+		{ScanStatusCancelled, true},
+		// Statuses added to the API later are kept as sent
+		{ScanStatus("paused"), false},
+		{ScanStatus("timed_out"), false},
+		{ScanStatus(""), false},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(Scan{ID: "s", Status: tt.status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var scan Scan
+		if err := json.Unmarshal(data, &scan); err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+		if scan.Status != tt.status {
+			t.Errorf("status %q decoded as %q", tt.status, scan.Status)
+		}
+		if scan.Status.IsTerminal() != tt.terminal {
+			t.Errorf("%q.IsTerminal() = %v, want %v", tt.status, scan.Status.IsTerminal(), tt.terminal)
+		}
+	}
+}
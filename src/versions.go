@@ -0,0 +1,243 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrNoMatchingVersion is returned when no published version satisfies a constraint
+var ErrNoMatchingVersion = errors.New("tavo: no version matches the constraint")
+
+// ResolveOptions configures version resolution
+type ResolveOptions struct {
+	// Let prerelease versions satisfy any range, not only ranges that name a
+	// prerelease of the same version
+	IncludePrerelease bool
+}
+
+// ResolveVersion returns the highest published version of a plugin that
+// satisfies constraint, e.g. "^1.2", "~2.0.3", ">=1.0 <2.0" or "latest"
+func (c *PluginMarketplaceClient) ResolveVersion(pluginID, constraint string, opts *ResolveOptions) (string, error) {
+	return c.ResolveVersionWithContext(context.Background(), pluginID, constraint, opts)
+}
+
+// ResolveVersionWithContext returns the highest published version of a plugin
+// that satisfies constraint, e.g. "^1.2", "~2.0.3", ">=1.0 <2.0" or "latest"
+func (c *PluginMarketplaceClient) ResolveVersionWithContext(ctx context.Context, pluginID, constraint string, opts *ResolveOptions) (string, error) {
+	versions, err := c.Get{plugin_id}versionsWithContext(ctx, pluginID)
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion("plugin "+pluginID, constraint, versions, opts)
+}
+
+// ResolveBundleVersion returns the highest published version of a rule bundle
+// that satisfies constraint
+func (c *RegistryClient) ResolveBundleVersion(bundleID, constraint string, opts *ResolveOptions) (string, error) {
+	return c.ResolveBundleVersionWithContext(context.Background(), bundleID, constraint, opts)
+}
+
+// ResolveBundleVersionWithContext returns the highest published version of a
+// rule bundle that satisfies constraint
+func (c *RegistryClient) ResolveBundleVersionWithContext(ctx context.Context, bundleID, constraint string, opts *ResolveOptions) (string, error) {
+	versions, err := c.Getbundles{bundle_id}versionsWithContext(ctx, bundleID)
+	if err != nil {
+		return "", err
+	}
+	return resolveVersion("bundle "+bundleID, constraint, versions, opts)
+}
+
+// resolveVersion picks the highest version in a versions response that satisfies constraint
+func resolveVersion(subject, constraint string, result interface{}, opts *ResolveOptions) (string, error) {
+	if opts == nil {
+		opts = &ResolveOptions{}
+	}
+	parsed, err := ParseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	versions, err := versionStrings(result)
+	if err != nil {
+		return "", err
+	}
+	version, ok := parsed.Latest(versions, opts.IncludePrerelease)
+	if !ok {
+		return "", fmt.Errorf("%w: %s has no version matching %q", ErrNoMatchingVersion, subject, constraint)
+	}
+	return version, nil
+}
+
+// publishedVersion is one entry of a versions response
+type publishedVersion struct {
+	Version string `json:"version"`
+	Yanked  bool   `json:"yanked"`
+}
+
+// versionStrings extracts the version strings from a versions response, which
+// may be a list of strings or of version objects, bare or wrapped in an object.
+// Yanked versions are left out.
+func versionStrings(result interface{}) ([]string, error) {
+	if envelope, ok := result.(map[string]interface{}); ok {
+		for _, key := range []string{"versions", "items", "data", "results"} {
+			if list, ok := envelope[key].([]interface{}); ok {
+				result = list
+				break
+			}
+		}
+	}
+	list, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("tavo: unexpected versions response %T", result)
+	}
+
+	versions := make([]string, 0, len(list))
+	for _, entry := range list {
+		if version, ok := entry.(string); ok {
+			versions = append(versions, version)
+			continue
+		}
+		var published publishedVersion
+		if err := decodeResult(entry, &published); err != nil {
+			return nil, err
+		}
+		if published.Version != "" && !published.Yanked {
+			versions = append(versions, published.Version)
+		}
+	}
+	return versions, nil
+}
+
+// PluginUpdate describes a newer version of an installed plugin
+type PluginUpdate struct {
+	PluginID  string
+	Name      string
+	Installed string
+
+	// Highest version satisfying the plugin's constraint, or "" if none is newer
+	// than the installed one
+	Compatible string
+
+	// Highest version overall, which may be outside the constraint
+	Latest string
+}
+
+// Breaking reports whether the latest version falls outside the constraint
+func (u PluginUpdate) Breaking() bool {
+	return u.Latest != "" && u.Latest != u.Compatible
+}
+
+// UpdateCheckOptions configures CheckUpdates
+type UpdateCheckOptions struct {
+	// Constraint per plugin ID. Plugins without one accept versions compatible
+	// with the installed version, i.e. "^<installed>".
+	Constraints map[string]string
+
+	IncludePrerelease bool
+}
+
+// installedPlugin is one entry of the installed plugins response
+type installedPlugin struct {
+	PluginID         string `json:"plugin_id"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	InstalledVersion string `json:"installed_version"`
+}
+
+// CheckUpdates compares the installed plugins against their published versions
+// and returns the plugins that have a newer version, sorted by plugin ID
+func (c *PluginMarketplaceClient) CheckUpdates(opts *UpdateCheckOptions) ([]PluginUpdate, error) {
+	return c.CheckUpdatesWithContext(context.Background(), opts)
+}
+
+// CheckUpdatesWithContext compares the installed plugins against their
+// published versions and returns the plugins that have a newer version, sorted
+// by plugin ID
+func (c *PluginMarketplaceClient) CheckUpdatesWithContext(ctx context.Context, opts *UpdateCheckOptions) ([]PluginUpdate, error) {
+	if opts == nil {
+		opts = &UpdateCheckOptions{}
+	}
+	result, err := c.GetinstalledWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if envelope, ok := result.(map[string]interface{}); ok {
+		for _, key := range []string{"plugins", "items", "data", "results"} {
+			if list, ok := envelope[key]; ok {
+				result = list
+				break
+			}
+		}
+	}
+	var installed []installedPlugin
+	if err := decodeResult(result, &installed); err != nil {
+		return nil, fmt.Errorf("tavo: unexpected installed plugins response: %w", err)
+	}
+
+	updates := []PluginUpdate{}
+	for _, plugin := range installed {
+		update, err := c.checkUpdate(ctx, plugin, opts)
+		if err != nil {
+			return nil, err
+		}
+		if update != nil {
+			updates = append(updates, *update)
+		}
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].PluginID < updates[j].PluginID })
+	return updates, nil
+}
+
+// checkUpdate looks up newer versions of one installed plugin
+func (c *PluginMarketplaceClient) checkUpdate(ctx context.Context, plugin installedPlugin, opts *UpdateCheckOptions) (*PluginUpdate, error) {
+	update := PluginUpdate{PluginID: plugin.PluginID, Name: plugin.Name, Installed: plugin.InstalledVersion}
+	if update.PluginID == "" {
+		update.PluginID = plugin.ID
+	}
+	if update.Installed == "" {
+		update.Installed = plugin.Version
+	}
+	installed, err := ParseVersion(update.Installed)
+	if err != nil {
+		// Without a comparable installed version there is nothing to report
+		return nil, nil
+	}
+
+	result, err := c.Get{plugin_id}versionsWithContext(ctx, update.PluginID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := versionStrings(result)
+	if err != nil {
+		return nil, err
+	}
+
+	constraint := opts.Constraints[update.PluginID]
+	if constraint == "" {
+		constraint = "^" + installed.String()
+	}
+	compatible, err := ParseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	latest, _ := ParseConstraint("latest")
+
+	if version, ok := compatible.Latest(versions, opts.IncludePrerelease); ok && newerVersion(version, installed) {
+		update.Compatible = version
+	}
+	if version, ok := latest.Latest(versions, opts.IncludePrerelease); ok && newerVersion(version, installed) {
+		update.Latest = version
+	}
+	if update.Compatible == "" && update.Latest == "" {
+		return nil, nil
+	}
+	return &update, nil
+}
+
+// newerVersion reports whether version orders after installed
+func newerVersion(version string, installed Version) bool {
+	v, err := ParseVersion(version)
+	return err == nil && v.Compare(installed) > 0
+}
@@ -0,0 +1,85 @@
+package tavo
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// decodeJSON decodes a response body the way endpoint methods do
+func decodeJSON(t *testing.T, body string) interface{} {
+	t.Helper()
+	var result interface{}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestVersionStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"bare strings", `["1.0.0", "1.1.0"]`, []string{"1.0.0", "1.1.0"}},
+		{"bare objects", `[{"version": "1.0.0"}, {"version": "1.1.0", "yanked": true}, {"version": "1.2.0", "yanked": false}]`, []string{"1.0.0", "1.2.0"}},
+		{"mixed entries", `["1.0.0", {"version": "1.1.0", "yanked": true}, {"version": "1.2.0"}, {"name": "no version"}]`, []string{"1.0.0", "1.2.0"}},
+		{"versions envelope", `{"plugin_id": "p", "versions": [{"version": "2.0.0", "yanked": true}, {"version": "2.0.1"}]}`, []string{"2.0.1"}},
+		{"items envelope", `{"items": ["1.0.0"], "total": 1}`, []string{"1.0.0"}},
+		{"data envelope", `{"data": [{"version": "3.0.0"}]}`, []string{"3.0.0"}},
+		{"results envelope", `{"results": [{"version": "4.0.0", "yanked": true}]}`, []string{}},
+		{"empty", `[]`, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := versionStrings(decodeJSON(t, tt.body))
+			if err != nil {
+				t.Fatalf("versionStrings: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("versionStrings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, body := range []string{`{"versions": "1.0.0"}`, `{"count": 2}`, `"1.0.0"`, `[{"version": 1}]`} {
+		if got, err := versionStrings(decodeJSON(t, body)); err == nil {
+			t.Errorf("versionStrings(%s) = %q, want an error", body, got)
+		}
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	body := decodeJSON(t, `{"versions": [
+		{"version": "1.2.0"},
+		{"version": "1.4.0", "yanked": true},
+		{"version": "1.3.1"},
+		{"version": "2.0.0-beta.1"}
+	]}`)
+
+	tests := []struct {
+		constraint string
+		opts       *ResolveOptions
+		want       string
+	}{
+		{"^1.2", nil, "1.3.1"},
+		{"latest", nil, "1.3.1"},
+		{"latest", &ResolveOptions{IncludePrerelease: true}, "2.0.0-beta.1"},
+		{"^2.0.0-beta", nil, "2.0.0-beta.1"},
+	}
+	for _, tt := range tests {
+		got, err := resolveVersion("plugin p", tt.constraint, body, tt.opts)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveVersion(%q) = %q, %v; want %q", tt.constraint, got, err, tt.want)
+		}
+	}
+
+	if _, err := resolveVersion("plugin p", "1.4.0", body, nil); !errors.Is(err, ErrNoMatchingVersion) {
+		t.Errorf("resolving a yanked version: err = %v, want ErrNoMatchingVersion", err)
+	}
+	if _, err := resolveVersion("plugin p", "^1.2.3.4", body, nil); err == nil {
+		t.Error("resolveVersion accepted an invalid constraint")
+	}
+}
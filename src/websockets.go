@@ -0,0 +1,478 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventType identifies the kind of a live event
+type EventType string
+
+const (
+	EventScanProgress     EventType = "scan.progress"
+	EventScanCompleted    EventType = "scan.completed"
+	EventJobStatus        EventType = "job.status"
+	EventAnalysisComplete EventType = "analysis.complete"
+)
+
+// ScanProgressEvent reports the progress of a running scan. It is also the
+// payload of EventScanCompleted.
+type ScanProgressEvent struct {
+	ScanID        string     `json:"scan_id"`
+	Status        ScanStatus `json:"status"`
+	Progress      float64    `json:"progress"`
+	Message       string     `json:"message,omitempty"`
+	FindingsCount int        `json:"findings_count,omitempty"`
+}
+
+// JobStatusEvent reports a change in a background job
+type JobStatusEvent struct {
+	JobID    string  `json:"job_id"`
+	JobType  string  `json:"job_type,omitempty"`
+	Status   string  `json:"status"`
+	Progress float64 `json:"progress,omitempty"`
+	Message  string  `json:"message,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// AnalysisCompleteEvent reports that an AI analysis has finished
+type AnalysisCompleteEvent struct {
+	AnalysisID   string                 `json:"analysis_id"`
+	ScanID       string                 `json:"scan_id,omitempty"`
+	AnalysisType string                 `json:"analysis_type,omitempty"`
+	Status       string                 `json:"status"`
+	Summary      map[string]interface{} `json:"summary,omitempty"`
+}
+
+// Event is a message received from the live event stream. Payload holds a
+// *ScanProgressEvent, *JobStatusEvent or *AnalysisCompleteEvent for known event
+// types and is nil otherwise; Data always holds the raw payload.
+type Event struct {
+	Type      EventType
+	Channel   string
+	Timestamp time.Time
+	Payload   interface{}
+	Data      json.RawMessage
+}
+
+// wireMessage is the JSON framing used on the websocket
+type wireMessage struct {
+	Type      EventType       `json:"type"`
+	Channel   string          `json:"channel,omitempty"`
+	Timestamp json.RawMessage `json:"timestamp,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// subscriptionMessage asks the server to start or stop sending a channel
+type subscriptionMessage struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+}
+
+// ScanChannel returns the channel carrying events for a scan
+func ScanChannel(scanID string) string { return "scan:" + scanID }
+
+// JobChannel returns the channel carrying events for a job
+func JobChannel(jobID string) string { return "job:" + jobID }
+
+// AnalysisChannel returns the channel carrying analysis events for a scan
+func AnalysisChannel(scanID string) string { return "analysis:" + scanID }
+
+// StreamOptions configures an EventStream
+type StreamOptions struct {
+	// Path of the websocket endpoint, relative to the API root
+	Path string
+
+	// Capacity of the Events channel
+	BufferSize int
+
+	// Interval between keepalive pings; the connection is considered dead
+	// when no message or pong arrives within twice this interval. A negative
+	// interval disables pings.
+	PingInterval time.Duration
+
+	// Delay bounds for reconnect attempts
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+
+	// Consecutive failed reconnects before the stream gives up; zero retries forever
+	MaxReconnectAttempts int
+}
+
+// DefaultStreamOptions returns the options used when Connect is given nil.
+// Fields left zero in the options passed to Connect are taken from here.
+func DefaultStreamOptions() *StreamOptions {
+	return &StreamOptions{
+		Path:              "/ws",
+		BufferSize:        64,
+		PingInterval:      30 * time.Second,
+		MinReconnectDelay: 500 * time.Millisecond,
+		MaxReconnectDelay: 30 * time.Second,
+	}
+}
+
+// merge returns a copy of opts with every unset field taken from o
+func (o *StreamOptions) merge(opts *StreamOptions) StreamOptions {
+	if opts == nil {
+		return *o
+	}
+	merged := *opts
+	if merged.Path == "" {
+		merged.Path = o.Path
+	}
+	if merged.BufferSize <= 0 {
+		merged.BufferSize = o.BufferSize
+	}
+	if merged.PingInterval == 0 {
+		merged.PingInterval = o.PingInterval
+	}
+	if merged.MinReconnectDelay <= 0 {
+		merged.MinReconnectDelay = o.MinReconnectDelay
+	}
+	if merged.MaxReconnectDelay <= 0 {
+		merged.MaxReconnectDelay = o.MaxReconnectDelay
+	}
+	return merged
+}
+
+// WebsocketsClient opens live event streams
+type WebsocketsClient struct {
+	client *Client
+}
+
+// EventStream is a live websocket connection that reconnects with backoff and
+// restores its subscriptions after a drop
+type EventStream struct {
+	client *Client
+	opts   StreamOptions
+	events chan Event
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	channels map[string]struct{}
+	err      error
+
+	writeMu sync.Mutex
+}
+
+// Connect opens an event stream authenticated with the client's API key or
+// device token. The stream runs until Close is called or ctx is done.
+func (w *WebsocketsClient) Connect(ctx context.Context, opts *StreamOptions) (*EventStream, error) {
+	options := DefaultStreamOptions().merge(opts)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &EventStream{
+		client:   w.client,
+		opts:     options,
+		events:   make(chan Event, options.BufferSize),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		channels: make(map[string]struct{}),
+	}
+
+	conn, err := stream.dial(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream.setConn(conn)
+
+	go stream.run(ctx, conn)
+	return stream, nil
+}
+
+// Events returns the channel events are delivered on. It is closed when the stream stops.
+func (s *EventStream) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the error that stopped the stream, once Events is closed
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Subscribe starts delivery of events for channel, e.g. ScanChannel(id).
+// Subscriptions are replayed automatically after a reconnect.
+func (s *EventStream) Subscribe(channel string) error {
+	s.mu.Lock()
+	s.channels[channel] = struct{}{}
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return s.write(conn, subscriptionMessage{Action: "subscribe", Channel: channel})
+}
+
+// Unsubscribe stops delivery of events for channel
+func (s *EventStream) Unsubscribe(channel string) error {
+	s.mu.Lock()
+	delete(s.channels, channel)
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return s.write(conn, subscriptionMessage{Action: "unsubscribe", Channel: channel})
+}
+
+// SubscribeScan subscribes to progress and completion events for a scan
+func (s *EventStream) SubscribeScan(scanID string) error {
+	return s.Subscribe(ScanChannel(scanID))
+}
+
+// SubscribeJob subscribes to status events for a job
+func (s *EventStream) SubscribeJob(jobID string) error {
+	return s.Subscribe(JobChannel(jobID))
+}
+
+// SubscribeAnalysis subscribes to analysis-complete events for a scan
+func (s *EventStream) SubscribeAnalysis(scanID string) error {
+	return s.Subscribe(AnalysisChannel(scanID))
+}
+
+// Close stops the stream and waits for it to shut down
+func (s *EventStream) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// streamURL converts the client's base URL into the websocket endpoint URL
+func (s *EventStream) streamURL() (string, error) {
+	u, err := url.Parse(s.client.baseURL + s.client.apiPath + s.opts.Path)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+	return u.String(), nil
+}
+
+// dial opens an authenticated websocket connection
+func (s *EventStream) dial(ctx context.Context) (*websocket.Conn, error) {
+	endpoint, err := s.streamURL()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.client.userAgent)
+	s.client.authorize(req)
+
+	dialer := *websocket.DefaultDialer
+	conn, resp, err := dialer.DialContext(ctx, endpoint, req.Header)
+	if err != nil {
+		if resp != nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+			defer resp.Body.Close()
+			return nil, newAPIError(resp)
+		}
+		return nil, fmt.Errorf("connect event stream: %w", err)
+	}
+	return conn, nil
+}
+
+// setConn records the active connection
+func (s *EventStream) setConn(conn *websocket.Conn) {
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+}
+
+// write sends a JSON message, serializing concurrent writers
+func (s *EventStream) write(conn *websocket.Conn, v interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	return conn.WriteJSON(v)
+}
+
+// resubscribe replays all subscriptions on a fresh connection
+func (s *EventStream) resubscribe(conn *websocket.Conn) error {
+	s.mu.Lock()
+	channels := make([]string, 0, len(s.channels))
+	for channel := range s.channels {
+		channels = append(channels, channel)
+	}
+	s.mu.Unlock()
+
+	for _, channel := range channels {
+		if err := s.write(conn, subscriptionMessage{Action: "subscribe", Channel: channel}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run reads from the connection and reconnects after drops until ctx is done
+func (s *EventStream) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(s.done)
+	defer close(s.events)
+
+	for {
+		err := s.readLoop(ctx, conn)
+		s.setConn(nil)
+		conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+
+		conn, err = s.reconnect(ctx, err)
+		if err != nil {
+			s.mu.Lock()
+			if ctx.Err() == nil {
+				s.err = err
+			}
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// reconnect dials again with exponential backoff and restores subscriptions
+func (s *EventStream) reconnect(ctx context.Context, cause error) (*websocket.Conn, error) {
+	policy := &RetryPolicy{
+		InitialBackoff: s.opts.MinReconnectDelay,
+		MaxBackoff:     s.opts.MaxReconnectDelay,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+
+	lastErr := cause
+	for attempt := 1; s.opts.MaxReconnectAttempts <= 0 || attempt <= s.opts.MaxReconnectAttempts; attempt++ {
+		if err := sleep(ctx, policy.backoff(attempt)); err != nil {
+			return nil, err
+		}
+
+		conn, err := s.dial(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		s.setConn(conn)
+		if err := s.resubscribe(conn); err != nil {
+			s.setConn(nil)
+			conn.Close()
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("event stream reconnect failed: %w", lastErr)
+}
+
+// readLoop delivers messages from conn until it fails or ctx is done
+func (s *EventStream) readLoop(ctx context.Context, conn *websocket.Conn) error {
+	stop := context.AfterFunc(ctx, func() {
+		s.writeMu.Lock()
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		s.writeMu.Unlock()
+		conn.Close()
+	})
+	defer stop()
+
+	if s.opts.PingInterval > 0 {
+		deadline := 2 * s.opts.PingInterval
+		conn.SetReadDeadline(time.Now().Add(deadline))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(deadline))
+		})
+
+		pingDone := make(chan struct{})
+		defer close(pingDone)
+		go s.keepalive(conn, pingDone)
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if s.opts.PingInterval > 0 {
+			conn.SetReadDeadline(time.Now().Add(2 * s.opts.PingInterval))
+		}
+
+		var msg wireMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			// Skip messages that are not events rather than dropping the connection
+			continue
+		}
+
+		event := decodeEvent(msg)
+		select {
+		case s.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// keepalive pings the server until done is closed
+func (s *EventStream) keepalive(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(s.opts.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			s.writeMu.Lock()
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+			s.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// decodeEvent converts a wire message into an Event with a typed payload
+func decodeEvent(msg wireMessage) Event {
+	event := Event{
+		Type:    msg.Type,
+		Channel: msg.Channel,
+		Data:    msg.Data,
+	}
+	// A missing or malformed timestamp is replaced by the time of receipt
+	var timestamp Timestamp
+	if len(msg.Timestamp) > 0 && json.Unmarshal(msg.Timestamp, &timestamp) == nil {
+		event.Timestamp = timestamp.Time
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	var payload interface{}
+	switch msg.Type {
+	case EventScanProgress, EventScanCompleted:
+		payload = &ScanProgressEvent{}
+	case EventJobStatus:
+		payload = &JobStatusEvent{}
+	case EventAnalysisComplete:
+		payload = &AnalysisCompleteEvent{}
+	}
+	if payload != nil && len(msg.Data) > 0 && json.Unmarshal(msg.Data, payload) == nil {
+		event.Payload = payload
+	}
+	return event
+}
@@ -0,0 +1,188 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// eventServer upgrades requests to /api/v1/ws and hands each connection to
+// handle, counting the connections made
+type eventServer struct {
+	*httptest.Server
+	connections atomic.Int32
+}
+
+func newEventServer(t *testing.T, handle func(n int32, conn *websocket.Conn)) *eventServer {
+	t.Helper()
+	server := &eventServer{}
+	upgrader := websocket.Upgrader{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ws" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(server.connections.Add(1), conn)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// nextEvent waits for the next event on stream
+func nextEvent(t *testing.T, stream *EventStream) Event {
+	t.Helper()
+	select {
+	case event, ok := <-stream.Events():
+		if !ok {
+			t.Fatalf("stream stopped: %v", stream.Err())
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return Event{}
+}
+
+// waitClosed holds the connection open until the client goes away
+func waitClosed(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func TestConnectFillsUnsetOptions(t *testing.T) {
+	server := newEventServer(t, func(n int32, conn *websocket.Conn) { waitClosed(conn) })
+	client := NewClient("test-key", "", server.URL)
+
+	opts := &StreamOptions{BufferSize: 3}
+	stream, err := client.Websockets().Connect(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer stream.Close()
+
+	if *opts != (StreamOptions{BufferSize: 3}) {
+		t.Errorf("Connect modified the caller's options: %+v", *opts)
+	}
+	defaults := DefaultStreamOptions()
+	want := *defaults
+	want.BufferSize = 3
+	if stream.opts != want {
+		t.Errorf("stream options = %+v, want %+v", stream.opts, want)
+	}
+	if cap(stream.events) != 3 {
+		t.Errorf("events capacity = %d, want 3", cap(stream.events))
+	}
+	if *defaults != *DefaultStreamOptions() {
+		t.Errorf("defaults changed to %+v", *defaults)
+	}
+}
+
+func TestConnectRejectsBadCredentials(t *testing.T) {
+	server := newEventServer(t, func(n int32, conn *websocket.Conn) {})
+	client := NewClient("wrong-key", "", server.URL)
+
+	if _, err := client.Websockets().Connect(context.Background(), nil); !IsUnauthorized(err) {
+		t.Errorf("err = %v, want HTTP 401", err)
+	}
+}
+
+func TestEventStreamSkipsUndecodableMessages(t *testing.T) {
+	server := newEventServer(t, func(n int32, conn *websocket.Conn) {
+		for _, msg := range []string{
+			`not json`,
+			`{"type": 42}`,
+			`{"type": "job.status", "channel": ["job:1"]}`,
+			`{"type": "job.status", "channel": "job:1", "timestamp": "last tuesday", "data": {"job_id": "1", "status": "running"}}`,
+			`{"type": "scan.progress", "timestamp": 1714557600, "data": {"scan_id": "s", "progress": "half"}}`,
+			`{"type": "scan.progress", "channel": "scan:s", "timestamp": "2024-05-01T10:00:00Z", "data": {"scan_id": "s", "progress": 50}}`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(msg))
+		}
+		waitClosed(conn)
+	})
+	client := NewClient("test-key", "", server.URL)
+
+	stream, err := client.Websockets().Connect(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer stream.Close()
+
+	job := nextEvent(t, stream)
+	if status, ok := job.Payload.(*JobStatusEvent); !ok || status.Status != "running" || job.Timestamp.IsZero() {
+		t.Errorf("event with a bad timestamp = %+v", job)
+	}
+
+	// Delivered with the raw payload only, since progress is not a number
+	untyped := nextEvent(t, stream)
+	if untyped.Type != EventScanProgress || untyped.Payload != nil || len(untyped.Data) == 0 {
+		t.Errorf("event with a bad payload = %+v", untyped)
+	}
+
+	progress := nextEvent(t, stream)
+	if payload, ok := progress.Payload.(*ScanProgressEvent); !ok || payload.Progress != 50 {
+		t.Errorf("progress event = %+v", progress)
+	}
+	if want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC); !progress.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", progress.Timestamp, want)
+	}
+	if n := server.connections.Load(); n != 1 {
+		t.Errorf("stream connected %d times, want 1", n)
+	}
+}
+
+func TestEventStreamResubscribesAfterReconnect(t *testing.T) {
+	server := newEventServer(t, func(n int32, conn *websocket.Conn) {
+		var sub subscriptionMessage
+		if err := conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		conn.WriteJSON(map[string]interface{}{
+			"type":    "scan.progress",
+			"channel": sub.Channel,
+			"data":    map[string]interface{}{"scan_id": "s", "progress": n * 10},
+		})
+		if n > 1 {
+			waitClosed(conn)
+		}
+	})
+	client := NewClient("test-key", "", server.URL)
+
+	stream, err := client.Websockets().Connect(context.Background(), &StreamOptions{MinReconnectDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := stream.SubscribeScan("s"); err != nil {
+		t.Fatalf("SubscribeScan: %v", err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		event := nextEvent(t, stream)
+		payload, ok := event.Payload.(*ScanProgressEvent)
+		if !ok || event.Channel != ScanChannel("s") || payload.Progress != float64(i*10) {
+			t.Errorf("event %d = %+v", i, event)
+		}
+	}
+
+	stream.Close()
+	if _, ok := <-stream.Events(); ok {
+		t.Error("Events is still open after Close")
+	}
+}